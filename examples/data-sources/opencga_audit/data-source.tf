@@ -0,0 +1,9 @@
+data "opencga_audit" "acl_changes" {
+  action = "UPDATE_ACLS"
+  study  = "user@project:a_cohort"
+  date   = ">=20230101"
+}
+
+output "acl_changes" {
+  value = [for r in data.opencga_audit.acl_changes.records : "${r.date} ${r.user} ${r.resource} ${r.resource_id}"]
+}
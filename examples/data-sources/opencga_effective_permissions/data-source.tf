@@ -0,0 +1,13 @@
+data "opencga_effective_permissions" "alice_na12877" {
+  study       = opencga_study.a_cohort.id
+  user        = "alice"
+  entity_type = "sample"
+  entity      = "NA12877_WGS"
+}
+
+check "alice_can_view_variants" {
+  assert {
+    condition     = contains(data.opencga_effective_permissions.alice_na12877.permissions, "VIEW_VARIANTS")
+    error_message = "alice cannot view the variants of NA12877_WGS"
+  }
+}
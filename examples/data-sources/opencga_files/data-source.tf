@@ -0,0 +1,10 @@
+data "opencga_files" "batch_1_vcfs" {
+  study     = data.opencga_study.a_cohort.fqn
+  path_glob = "data/batch_1/**/*.vcf.gz"
+  format    = "VCF"
+}
+
+resource "opencga_variant_index" "batch_1" {
+  study = data.opencga_study.a_cohort.fqn
+  files = data.opencga_files.batch_1_vcfs.ids
+}
@@ -0,0 +1,17 @@
+data "opencga_jobs" "active" {
+  study  = opencga_study.a_cohort.id
+  status = "PENDING,QUEUED,RUNNING"
+}
+
+resource "opencga_variant_operation" "rebuild_sample_index" {
+  operation = "SAMPLE_INDEX"
+  study     = opencga_study.a_cohort.id
+  overwrite = true
+
+  lifecycle {
+    precondition {
+      condition     = length(data.opencga_jobs.active.ids) == 0
+      error_message = "Jobs still running: ${join(", ", data.opencga_jobs.active.ids)}"
+    }
+  }
+}
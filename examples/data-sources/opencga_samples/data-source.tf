@@ -0,0 +1,21 @@
+data "opencga_samples" "somatic" {
+  study           = data.opencga_study.a_cohort.fqn
+  somatic         = "true"
+  creation_date   = ">=20230101"
+  internal_status = "READY"
+  include         = ["individualId"]
+}
+
+resource "opencga_annotation_set" "tumour_qc" {
+  for_each = toset(data.opencga_samples.somatic.ids)
+
+  study        = data.opencga_study.a_cohort.id
+  entity_type  = "sample"
+  entity       = each.value
+  name         = "tumour_qc"
+  variable_set = opencga_variableset.tumour_qc.id
+
+  annotations = {
+    reviewed = "false"
+  }
+}
@@ -0,0 +1,16 @@
+data "opencga_server" "current" {}
+
+resource "opencga_study" "a_cohort" {
+  # ...
+
+  lifecycle {
+    precondition {
+      condition     = data.opencga_server.current.healthy
+      error_message = "OpenCGA is degraded: ${jsonencode(data.opencga_server.current.status)}"
+    }
+    precondition {
+      condition     = startswith(data.opencga_server.current.version, "2.")
+      error_message = "OpenCGA 2.x is required, found ${data.opencga_server.current.version}"
+    }
+  }
+}
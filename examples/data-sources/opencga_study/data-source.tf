@@ -0,0 +1,4 @@
+data "opencga_study" "a_cohort" {
+  study   = "a_cohort"
+  project = data.opencga_project.germline_cohort.id
+}
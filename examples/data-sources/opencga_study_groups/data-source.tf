@@ -0,0 +1,8 @@
+data "opencga_study_groups" "a_cohort" {
+  study     = opencga_study.a_cohort.id
+  id_filter = ["@members", "@analysts"]
+}
+
+output "analysts" {
+  value = [for g in data.opencga_study_groups.a_cohort.groups : g.users if g.id == "@analysts"][0]
+}
@@ -0,0 +1,16 @@
+data "opencga_study_stats" "a_cohort" {
+  study = opencga_study.a_cohort.id
+}
+
+check "release_ready" {
+  assert {
+    condition     = length(data.opencga_study_stats.a_cohort.alignment_files_without_samples) == 0
+    error_message = "CRAMs without a sample: ${join(", ", data.opencga_study_stats.a_cohort.alignment_files_without_samples)}"
+  }
+  assert {
+    condition = alltrue([
+      for status, count in data.opencga_study_stats.a_cohort.files_by_index_status : status == "READY" || count == 0
+    ])
+    error_message = "Not every VCF is indexed: ${jsonencode(data.opencga_study_stats.a_cohort.files_by_index_status)}"
+  }
+}
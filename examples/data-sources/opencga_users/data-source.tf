@@ -0,0 +1,12 @@
+data "opencga_users" "analysts" {
+  id_filter             = ["alice", "bob", "carol"]
+  authentication_origin = "ldap"
+}
+
+resource "opencga_study_group_member" "analysts" {
+  for_each = toset(data.opencga_users.analysts.ids)
+
+  study = opencga_study.a_cohort.id
+  group = "@analysts"
+  user  = each.value
+}
@@ -0,0 +1,14 @@
+data "opencga_variants" "truth_set" {
+  study  = opencga_study.a_cohort.id
+  sample = "NA12878"
+  region = "22"
+  limit  = 0
+  facet  = "type"
+}
+
+check "truth_set_indexed" {
+  assert {
+    condition     = data.opencga_variants.truth_set.total == 71924
+    error_message = "Expected 71924 variants for NA12878 on chr22, found ${data.opencga_variants.truth_set.total}"
+  }
+}
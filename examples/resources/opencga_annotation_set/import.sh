@@ -0,0 +1,2 @@
+# Annotation sets are imported using study/entity_type/entity/name
+terraform import opencga_annotation_set.consent 1000000001/individual/NA12877/consent
@@ -0,0 +1,26 @@
+resource "opencga_annotation_set" "consent" {
+  study        = opencga_study.a_cohort.id
+  entity_type  = "individual"
+  entity       = "NA12877"
+  name         = "consent"
+  variable_set = opencga_variableset.new_var_set.id
+
+  annotations = {
+    consent_given = "true"
+    consent_type  = "research"
+    withdrawn     = "false"
+  }
+}
+
+resource "opencga_annotation_set" "qc" {
+  study        = opencga_study.a_cohort.id
+  entity_type  = "sample"
+  entity       = "NA12877_WGS"
+  name         = "qc"
+  variable_set = "1000000013"
+
+  annotations_json = jsonencode({
+    coverage = 32.5
+    metrics  = { contamination = 0.01 }
+  })
+}
@@ -0,0 +1,2 @@
+# Clinical analyses are imported using study/name
+terraform import 'opencga_clinical_analysis.family_case["FAM001"]' 1000000001/CASE_FAM001
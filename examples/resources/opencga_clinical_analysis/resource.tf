@@ -0,0 +1,13 @@
+resource "opencga_clinical_analysis" "family_case" {
+  for_each = toset(["FAM001", "FAM002"])
+
+  study    = opencga_study.a_cohort.id
+  name     = "CASE_${each.key}"
+  type     = "FAMILY"
+  proband  = "${each.key}_PROBAND"
+  family   = each.key
+  panels   = ["intellectual_disability"]
+  analysts = ["user1"]
+  priority = "HIGH"
+  due_date = "20230101000000"
+}
@@ -0,0 +1,18 @@
+resource "opencga_entity_acl" "cram_download" {
+  study       = opencga_study.a_cohort.id
+  entity_type = "file"
+  entity_ids  = [opencga_file.cram.id]
+  member      = "@analysts"
+  permissions = ["VIEW", "VIEW_HEADER", "DOWNLOAD"]
+}
+
+resource "opencga_entity_acl" "somatic_samples" {
+  study       = opencga_study.a_cohort.id
+  entity_type = "sample"
+  query = {
+    somatic = "true"
+  }
+  member      = "@cancer"
+  permissions = ["VIEW", "VIEW_ANNOTATIONS", "VIEW_VARIANTS"]
+  propagate   = true
+}
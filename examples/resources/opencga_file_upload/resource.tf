@@ -0,0 +1,14 @@
+resource "opencga_file_upload" "pedigree" {
+  study     = opencga_study.a_cohort.id
+  source    = "${path.module}/families.ped"
+  path      = opencga_folder.releases.path
+  format    = "PED"
+  bioformat = "PEDIGREE"
+}
+
+resource "opencga_file_upload" "panel" {
+  study      = opencga_study.a_cohort.id
+  source     = "${path.module}/panels/cardio.bed"
+  path       = "panels/"
+  chunk_size = 5242880
+}
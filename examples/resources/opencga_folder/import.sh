@@ -0,0 +1,2 @@
+# Folders are imported using study/id
+terraform import opencga_folder.genomes 1000000001/1000000123
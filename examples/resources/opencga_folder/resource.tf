@@ -0,0 +1,16 @@
+resource "opencga_folder" "releases" {
+  study = opencga_study.a_cohort.id
+  path  = "releases/"
+}
+
+resource "opencga_folder" "genomes" {
+  study = opencga_study.a_cohort.id
+  path  = "releases/genomes/"
+  uri   = "/genomes"
+}
+
+resource "opencga_file" "cram" {
+  study = opencga_study.a_cohort.id
+  uri   = "/genomes/sample/A00001.cram"
+  path  = opencga_folder.genomes.path
+}
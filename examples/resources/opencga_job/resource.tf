@@ -0,0 +1,14 @@
+resource "opencga_job" "sample_qc" {
+  study       = opencga_study.a_cohort.id
+  tool        = "sample-qc"
+  description = "Sample QC for the first batch"
+  params = {
+    sample = "SAMPLE_1"
+  }
+  poll_interval   = 30
+  kill_on_destroy = true
+
+  timeouts {
+    create = "2h"
+  }
+}
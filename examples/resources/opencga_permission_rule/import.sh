@@ -0,0 +1,2 @@
+# Permission rules are imported using study/entity/name
+terraform import opencga_permission_rule.research_consent 1000000001/SAMPLES/research_consent
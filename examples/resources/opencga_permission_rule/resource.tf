@@ -0,0 +1,11 @@
+resource "opencga_permission_rule" "research_consent" {
+  study  = opencga_study.a_cohort.id
+  name   = "research_consent"
+  entity = "SAMPLES"
+  query = {
+    annotation = "consent.consent_type=research"
+  }
+  members        = ["@researchers"]
+  permissions    = ["VIEW", "VIEW_ANNOTATIONS"]
+  destroy_action = "REVERT"
+}
@@ -0,0 +1,2 @@
+# The policy is imported using the study id
+terraform import opencga_study_acl_policy.a_cohort 1000000001
@@ -0,0 +1,20 @@
+resource "opencga_study_acl_policy" "a_cohort" {
+  study = opencga_study.a_cohort.id
+
+  acl {
+    member   = "@members"
+    template = "view_only"
+  }
+
+  acl {
+    member   = opencga_user.analyst.user_id
+    template = "analyst"
+  }
+
+  acl {
+    member      = "@researchers"
+    permissions = ["VIEW_SAMPLES", "VIEW_FILES", "VIEW_INDIVIDUALS"]
+  }
+
+  excluded_members = ["admin", "@admins"]
+}
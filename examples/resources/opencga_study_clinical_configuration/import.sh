@@ -0,0 +1,2 @@
+# Clinical configuration is imported using the study id
+terraform import opencga_study_clinical_configuration.a_cohort 1000000001
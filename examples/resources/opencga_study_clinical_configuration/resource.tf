@@ -0,0 +1,63 @@
+resource "opencga_study_clinical_configuration" "a_cohort" {
+  study = opencga_study.a_cohort.id
+
+  priority {
+    id      = "URGENT"
+    rank    = 1
+    default = false
+  }
+  priority {
+    id      = "ROUTINE"
+    rank    = 2
+    default = true
+  }
+
+  flags {
+    clinical_type = "FAMILY"
+    value {
+      id          = "LOW_QUALITY"
+      description = "Sample quality is below the threshold"
+    }
+    value {
+      id = "MIXED_CHEMISTRIES"
+    }
+  }
+
+  status {
+    clinical_type = "FAMILY"
+    value {
+      id   = "READY_FOR_INTERPRETATION"
+      type = "NOT_STARTED"
+    }
+    value {
+      id   = "INTERPRETATION_IN_PROGRESS"
+      type = "IN_PROGRESS"
+    }
+    value {
+      id   = "REPORTED"
+      type = "CLOSED"
+    }
+  }
+
+  interpretation_status {
+    clinical_type = "FAMILY"
+    value {
+      id   = "NOT_REVIEWED"
+      type = "NOT_STARTED"
+    }
+    value {
+      id   = "REVIEWED"
+      type = "CLOSED"
+    }
+  }
+
+  interpretation_default_filter = jsonencode({
+    ct                     = "lof,missense_variant"
+    populationFrequencyAlt = "GNOMAD_GENOMES:ALL<0.01"
+  })
+
+  consent {
+    id   = "PRIMARY_FINDINGS"
+    name = "Primary findings"
+  }
+}
@@ -0,0 +1,13 @@
+resource "opencga_study_group" "analysts" {
+  study = opencga_study.a_cohort.id
+  name  = "analysts"
+
+  sync_from {
+    authentication_origin = "ldap"
+    remote_group          = "cn=analysts,ou=groups,dc=mycompany,dc=com"
+  }
+
+  sync_triggers = {
+    release = "2022-10"
+  }
+}
@@ -0,0 +1,2 @@
+# Group members are imported using study/group/user
+terraform import opencga_study_group_member.pipeline 1000000001/@members/analyst1
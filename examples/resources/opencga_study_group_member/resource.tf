@@ -0,0 +1,5 @@
+resource "opencga_study_group_member" "pipeline" {
+  study = opencga_study.a_cohort.id
+  group = "@members"
+  user  = opencga_user.analyst.user_id
+}
@@ -0,0 +1,22 @@
+resource "opencga_user" "analyst" {
+  user_id  = "analyst1"
+  name     = "Analyst One"
+  email    = "analyst1@mycompany.com"
+  password = var.initial_password
+
+  disk_quota = 200000000000
+}
+
+resource "opencga_user" "ldap_user" {
+  user_id               = "jbloggs"
+  name                  = "Joe Bloggs"
+  email                 = "jbloggs@mycompany.com"
+  authentication_origin = "ldap"
+  destroy_action        = "DELETE"
+}
+
+resource "opencga_study_acl" "analyst_acl" {
+  study    = opencga_study.a_cohort.alias
+  member   = opencga_user.analyst.user_id
+  template = "analyst"
+}
@@ -0,0 +1,2 @@
+# Variant engine configuration is imported using the project id
+terraform import opencga_variant_engine_configuration.a_project 1000000000
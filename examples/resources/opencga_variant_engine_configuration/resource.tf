@@ -0,0 +1,16 @@
+resource "opencga_variant_engine_configuration" "a_project" {
+  project = opencga_project.a_project.id
+
+  cellbase {
+    url          = "https://ws.zettagenomics.com/cellbase"
+    version      = "v5.1"
+    data_release = "2"
+  }
+  annotation_update = true
+
+  variant_options = {
+    "annotator"                     = "cellbase"
+    "search.intersect.active"       = "true"
+    "annotation.sampleIndex.enable" = "true"
+  }
+}
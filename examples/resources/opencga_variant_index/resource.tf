@@ -0,0 +1,14 @@
+resource "opencga_variant_index" "batch_1" {
+  study = opencga_study.a_cohort.id
+  files = [
+    opencga_file.batch_1_chr1.id,
+    opencga_file.batch_1_chr2.id,
+  ]
+  annotate        = true
+  calculate_stats = true
+
+  timeouts {
+    create = "6h"
+    update = "6h"
+  }
+}
@@ -0,0 +1,23 @@
+resource "opencga_variant_operation" "annotation" {
+  operation = "ANNOTATION_INDEX"
+  project   = opencga_project.a_project.id
+  triggers = {
+    release = var.data_release
+  }
+}
+
+resource "opencga_variant_operation" "secondary_index" {
+  operation = "SECONDARY_INDEX"
+  project   = opencga_project.a_project.id
+  triggers = {
+    annotation_job = opencga_variant_operation.annotation.job_id
+  }
+}
+
+resource "opencga_variant_operation" "sample_index" {
+  operation = "SAMPLE_INDEX"
+  study     = opencga_study.a_cohort.id
+  triggers = {
+    index_jobs = join(",", opencga_variant_index.batch_1.job_ids)
+  }
+}
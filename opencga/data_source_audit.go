@@ -0,0 +1,156 @@
+package opencga
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Query parameter used by each audit filter
+var audit_search_filters = map[string]string{
+	"user":        "userId",
+	"action":      "action",
+	"resource":    "resource",
+	"resource_id": "resourceId",
+	"study":       "studyId",
+	"status":      "status",
+	"date":        "date",
+}
+
+func dataSourceAudit() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to query the OpenCGA audit log, e.g. to export who changed ACLs. Requires an admin user",
+		ReadContext: dataSourceAuditRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"user": &schema.Schema{
+				Description: "Id of the user that made the changes",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"action": &schema.Schema{
+				Description: "Comma separated actions, e.g. UPDATE_ACLS,CREATE",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"resource": &schema.Schema{
+				Description: "Comma separated resource types, e.g. STUDY,SAMPLE",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"resource_id": &schema.Schema{
+				Description: "Id of the changed resource",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"study": &schema.Schema{
+				Description: "Study of the changed resources",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": &schema.Schema{
+				Description: "Result of the change, SUCCESS or ERROR",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"date": &schema.Schema{
+				Description: "Date or range in the format YYYYMMDD, e.g. `>=20230101` or `20230101-20231231`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"max_results": &schema.Schema{
+				Description:  "Maximum number of records to return, all matching records are returned by default",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Computed values
+			"records": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"study": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAuditRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	params := make(map[string]string)
+	for attribute, param := range audit_search_filters {
+		if v, ok := d.GetOk(attribute); ok {
+			params[param] = v.(string)
+		}
+	}
+
+	results, err := client.Search("admin/audit/query", params, d.Get("max_results").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var records []AuditRecord
+	err = mapstructure.Decode(results, &records)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, len(records))
+	for i, record := range records {
+		flattened[i] = map[string]interface{}{
+			"id":          record.Id,
+			"user":        record.UserId,
+			"action":      record.Action,
+			"resource":    record.Resource,
+			"resource_id": record.ResourceId,
+			"study":       record.StudyId,
+			"status":      record.Status.Name,
+			"date":        record.Date,
+		}
+	}
+
+	d.SetId(computeSearchDataSourceId(params))
+	if err := d.Set("records", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
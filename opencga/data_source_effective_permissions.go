@@ -0,0 +1,268 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Permission rule entity of each ACL entity type
+var permission_rule_entity_types = map[string]string{
+	"sample":     "SAMPLES",
+	"file":       "FILES",
+	"individual": "INDIVIDUALS",
+	"family":     "FAMILIES",
+	"cohort":     "COHORTS",
+	"panel":      "DISEASE_PANELS",
+	"job":        "JOBS",
+}
+
+func dataSourceEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to work out the permissions a user has on a study, or an entity of the study, " +
+			"and where each permission comes from. Permissions granted with a template are returned by OpenCGA " +
+			"already expanded, so they are listed against the member the template was applied to.",
+		ReadContext: dataSourceEffectivePermissionsRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study to check",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"user": &schema.Schema{
+				Description: "The user id to check",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"entity_type": &schema.Schema{
+				Description:  "Type of the entity to check, one of: sample, file, individual, family, cohort, panel, job",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"entity"},
+				ValidateFunc: validation.StringInSlice(acl_entity_types, false),
+			},
+			"entity": &schema.Schema{
+				Description:  "Id of the entity to check",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"entity_type"},
+			},
+			// Computed values
+			"groups": &schema.Schema{
+				Description: "Study groups the user belongs to",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"admin": &schema.Schema{
+				Description: "True when the user is a member of `@admins`, which has every permission",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"permissions": &schema.Schema{
+				Description: "Every permission the user has, from any source",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"sources": &schema.Schema{
+				Description: "Where each permission comes from",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permission": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"level": &schema.Schema{
+							Description: "study or entity",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"member": &schema.Schema{
+							Description: "The user, one of their groups or `*` for every user",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"permission_rule": &schema.Schema{
+							Description: "Permission rule that granted the permission, if any",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEffectivePermissionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	study := d.Get("study").(string)
+	user := d.Get("user").(string)
+
+	// Members whose permissions apply to the user
+	path := fmt.Sprintf("studies/%s/groups", study)
+	req, err := buildRequest(client, path, nil, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var studyGroups []StudyGroup
+	err = mapstructure.Decode(resp.Results, &studyGroups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	groups := make([]string, 0)
+	for _, group := range flattenStudyGroups(studyGroups) {
+		g := group.(map[string]interface{})
+		if stringInSlice(user, g["users"].([]string)) {
+			groups = append(groups, g["id"].(string))
+		}
+	}
+	members := append([]string{user, "*"}, groups...)
+
+	sources := make([]interface{}, 0)
+	addSources := func(level string, acls []StudyACL, rule string) {
+		for _, acl := range acls {
+			if !stringInSlice(acl.Member, members) {
+				continue
+			}
+			for _, permission := range acl.Permissions {
+				sources = append(sources, map[string]interface{}{
+					"permission":      permission,
+					"level":           level,
+					"member":          acl.Member,
+					"permission_rule": rule,
+				})
+			}
+		}
+	}
+
+	studyACLs, err := getStudyACLs(client, study)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	addSources("study", studyACLs, "")
+
+	if v, ok := d.GetOk("entity_type"); ok {
+		entityType := v.(string)
+		entity := d.Get("entity").(string)
+
+		entityACLs, err := getEntityACLs(client, study, entityType, entity)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Permissions granted by a rule are stored on the entity like any
+		// other ACL, so they are attributed to every rule that matches it
+		rules, err := getPermissionRules(client, study, permission_rule_entity_types[entityType])
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		fromRules := make(map[string]bool)
+		for _, rule := range rules {
+			query := map[string]interface{}{"id": entity}
+			for k, v := range rule.Query {
+				query[k] = v
+			}
+			count, err := countMatchingEntities(client, study, permission_rule_entity_types[entityType], query)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if count == 0 {
+				continue
+			}
+			for _, member := range rule.Members {
+				addSources("entity", []StudyACL{{Member: member, Permissions: rule.Permissions}}, rule.Id)
+				for _, permission := range rule.Permissions {
+					fromRules[member+"|"+permission] = true
+				}
+			}
+		}
+		direct := make([]StudyACL, len(entityACLs))
+		for i, acl := range entityACLs {
+			direct[i].Member = acl.Member
+			for _, permission := range acl.Permissions {
+				if !fromRules[acl.Member+"|"+permission] {
+					direct[i].Permissions = append(direct[i].Permissions, permission)
+				}
+			}
+		}
+		addSources("entity", direct, "")
+	}
+
+	permissions := make([]string, 0)
+	for _, source := range sources {
+		permission := source.(map[string]interface{})["permission"].(string)
+		if !stringInSlice(permission, permissions) {
+			permissions = append(permissions, permission)
+		}
+	}
+	sort.Strings(permissions)
+
+	d.SetId(fmt.Sprintf("%s|%s|%s|%s", study, user, d.Get("entity_type"), d.Get("entity")))
+	d.Set("groups", groups)
+	d.Set("admin", stringInSlice("@admins", groups))
+	d.Set("permissions", permissions)
+	if err := d.Set("sources", sources); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func getEntityACLs(client *APIClient, study string, entityType string, entity string) ([]StudyACL, error) {
+	// Entity ACLs have the same member and permissions form as study ACLs
+	path := fmt.Sprintf("%s/%s/acl", entity_paths[entityType], entity)
+	params := map[string]string{
+		"study": study,
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	var acls []StudyACL
+	err = mapstructure.Decode(resp.Results, &acls)
+	if err != nil {
+		return nil, err
+	}
+	return acls, nil
+}
+
+func getPermissionRules(client *APIClient, study string, entity string) ([]PermissionRule, error) {
+	path := fmt.Sprintf("studies/%s/permissionRules", study)
+	params := map[string]string{
+		"entity": entity,
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	var rules []PermissionRule
+	err = mapstructure.Decode(resp.Results, &rules)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
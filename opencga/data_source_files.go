@@ -0,0 +1,228 @@
+package opencga
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Query parameter used by each file filter that is passed through as it is
+var file_search_filters = map[string]string{
+	"format":       "format",
+	"bioformat":    "bioformat",
+	"samples":      "sampleIds",
+	"index_status": "internalVariantIndexStatus",
+	"tags":         "tags",
+}
+
+func dataSourceFiles() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to search the Files already registered in a study",
+		ReadContext: dataSourceFilesRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study to search",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"path_prefix": &schema.Schema{
+				Description:   "Catalog path the files must start with, e.g. `data/batch_1/`",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"path_glob"},
+			},
+			"path_glob": &schema.Schema{
+				Description:   "Glob matching the catalog path of the files. `*` and `?` do not match `/`, `**` matches any number of folders, e.g. `data/**/*.vcf.gz`",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"path_prefix"},
+			},
+			"name_regex": &schema.Schema{
+				Description:  "Regular expression matching the file names",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"format": &schema.Schema{
+				Description: "Comma separated file formats, e.g. VCF,BAM",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"bioformat": &schema.Schema{
+				Description: "Comma separated file bioformats, e.g. VARIANT,ALIGNMENT",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"samples": &schema.Schema{
+				Description: "Comma separated ids of samples the files belong to",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"index_status": &schema.Schema{
+				Description: "Variant index status of the files, e.g. READY, NONE",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": &schema.Schema{
+				Description: "Comma separated file tags",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": &schema.Schema{
+				Description:  "FILE or DIRECTORY",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "FILE",
+				ValidateFunc: validation.StringInSlice([]string{"FILE", "DIRECTORY"}, false),
+			},
+			"max_results": &schema.Schema{
+				Description:  "Maximum number of files to return, all matching files are returned by default",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Computed values
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"files": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"uri": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"format": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bioformat": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"checksum": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"index_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFilesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	params := map[string]string{
+		"study":   d.Get("study").(string),
+		"type":    d.Get("type").(string),
+		"include": "id,name,path,uri,format,bioformat,size,checksum,internal.variant.index",
+	}
+	// OpenCGA searches by regular expression when the value starts with ~
+	if v, ok := d.GetOk("path_prefix"); ok {
+		params["path"] = "~^" + regexp.QuoteMeta(v.(string))
+	}
+	if v, ok := d.GetOk("path_glob"); ok {
+		params["path"] = "~" + globToRegex(v.(string))
+	}
+	if v, ok := d.GetOk("name_regex"); ok {
+		params["name"] = "~" + v.(string)
+	}
+	for attribute, param := range file_search_filters {
+		if v, ok := d.GetOk(attribute); ok {
+			params[param] = v.(string)
+		}
+	}
+
+	results, err := client.Search("files/search", params, d.Get("max_results").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var files []File
+	err = mapstructure.Decode(results, &files)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, len(files))
+	flattened := make([]interface{}, len(files))
+	for i, file := range files {
+		ids[i] = strconv.Itoa(file.Id)
+		flattened[i] = map[string]interface{}{
+			"id":           ids[i],
+			"name":         file.Name,
+			"path":         file.Path,
+			"uri":          file.Uri,
+			"format":       file.Format,
+			"bioformat":    file.Bioformat,
+			"size":         file.Size,
+			"checksum":     file.Checksum,
+			"index_status": file.Internal.Variant.Index.Status.Name,
+		}
+	}
+
+	d.SetId(computeSearchDataSourceId(params))
+	d.Set("ids", ids)
+	if err := d.Set("files", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func globToRegex(glob string) string {
+	// Translate a path glob into an anchored regular expression
+	var regex strings.Builder
+	regex.WriteRune('^')
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			regex.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			regex.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			regex.WriteString("[^/]*")
+		case glob[i] == '?':
+			regex.WriteString("[^/]")
+		default:
+			regex.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	regex.WriteRune('$')
+	return regex.String()
+}
@@ -0,0 +1,145 @@
+package opencga
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Query parameter used by each job filter
+var job_search_filters = map[string]string{
+	"tool":          "tool",
+	"status":        "internalStatus",
+	"user":          "userId",
+	"creation_date": "creationDate",
+}
+
+func dataSourceJobs() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to search the jobs of a study, e.g. to check that no jobs are running before a destructive change",
+		ReadContext: dataSourceJobsRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study to search",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"tool": &schema.Schema{
+				Description: "Comma separated tool ids, e.g. variant-index",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": &schema.Schema{
+				Description: "Comma separated job statuses, e.g. PENDING,QUEUED,RUNNING",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"user": &schema.Schema{
+				Description: "Id of the user that submitted the jobs",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"creation_date": &schema.Schema{
+				Description: "Creation date or range in the format YYYYMMDD, e.g. `>=20230101` or `20230101-20231231`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"max_results": &schema.Schema{
+				Description:  "Maximum number of jobs to return, all matching jobs are returned by default",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Computed values
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"jobs": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tool": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"creation_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"output_files": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceJobsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	params := map[string]string{
+		"study":   d.Get("study").(string),
+		"include": "id,tool.id,userId,creationDate,internal.status,output.id",
+	}
+	for attribute, param := range job_search_filters {
+		if v, ok := d.GetOk(attribute); ok {
+			params[param] = v.(string)
+		}
+	}
+
+	results, err := client.Search("jobs/search", params, d.Get("max_results").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var jobs []Job
+	err = mapstructure.Decode(results, &jobs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, len(jobs))
+	flattened := make([]interface{}, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.Id
+		flattened[i] = map[string]interface{}{
+			"id":            job.Id,
+			"tool":          job.Tool.Id,
+			"status":        job.Internal.Status.Name,
+			"user":          job.UserId,
+			"creation_date": job.CreationDate,
+			"output_files":  flattenJobOutput(&jobs[i]),
+		}
+	}
+
+	d.SetId(computeSearchDataSourceId(params))
+	d.Set("ids", ids)
+	if err := d.Set("jobs", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
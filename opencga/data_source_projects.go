@@ -91,17 +91,13 @@ func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, m inter
 		params["name"] = v.(string)
 	}
 
-	req, err := buildRequest(client, path, nil, params)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	resp, err := client.Call(req)
+	results, err := client.Search(path, params, 0)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	projects := make([]Project, len(resp.Results))
-	err = mapstructure.Decode(resp.Results, &projects)
+	projects := make([]Project, len(results))
+	err = mapstructure.Decode(results, &projects)
 	if err != nil {
 		return diag.FromErr(err)
 	}
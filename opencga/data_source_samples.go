@@ -0,0 +1,197 @@
+package opencga
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Query parameter used by each sample filter
+var sample_search_filters = map[string]string{
+	"id_filter":       "id",
+	"individual":      "individualId",
+	"phenotypes":      "phenotypes",
+	"somatic":         "somatic",
+	"annotation":      "annotation",
+	"creation_date":   "creationDate",
+	"internal_status": "internalStatus",
+}
+
+func dataSourceSamples() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to search the Samples of a study, e.g. to create resources for every sample with `for_each`",
+		ReadContext: dataSourceSamplesRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study to search",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"id_filter": &schema.Schema{
+				Description: "Comma separated sample ids, a `~` prefix searches by regular expression, e.g. `~^NA12`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"individual": &schema.Schema{
+				Description: "Comma separated individual ids",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"phenotypes": &schema.Schema{
+				Description: "Comma separated phenotype ids or names",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"somatic": &schema.Schema{
+				Description:  "Limit to somatic (`true`) or germline (`false`) samples",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"true", "false"}, false),
+			},
+			"annotation": &schema.Schema{
+				Description: "Annotation expression, e.g. `consent.consent_type=research`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"creation_date": &schema.Schema{
+				Description: "Creation date or range in the format YYYYMMDD, e.g. `>=20230101` or `20230101-20231231`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"internal_status": &schema.Schema{
+				Description: "Internal status of the samples, e.g. READY",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"include": &schema.Schema{
+				Description: "OpenCGA fields returned for each sample to keep the state small, e.g. `[\"individualId\"]`. Fields left out are empty, all the fields of `samples` are returned by default",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"max_results": &schema.Schema{
+				Description:  "Maximum number of samples to return, all matching samples are returned by default",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Computed values
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"samples": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"individual_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"somatic": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"creation_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"phenotypes": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSamplesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	params := map[string]string{
+		"study":   d.Get("study").(string),
+		"include": "id,individualId,somatic,creationDate,phenotypes.id,internal.status",
+	}
+	if v, ok := d.GetOk("include"); ok {
+		// The id is always needed to identify the samples
+		include := []string{"id"}
+		for _, field := range v.([]interface{}) {
+			include = append(include, field.(string))
+		}
+		params["include"] = strings.Join(include, ",")
+	}
+	for attribute, param := range sample_search_filters {
+		if v, ok := d.GetOk(attribute); ok {
+			params[param] = v.(string)
+		}
+	}
+
+	results, err := client.Search("samples/search", params, d.Get("max_results").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var samples []Sample
+	err = mapstructure.Decode(results, &samples)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := make([]string, len(samples))
+	flattened := make([]interface{}, len(samples))
+	for i, sample := range samples {
+		ids[i] = sample.Id
+		flattened[i] = map[string]interface{}{
+			"id":            sample.Id,
+			"individual_id": sample.IndividualId,
+			"somatic":       sample.Somatic,
+			"creation_date": sample.CreationDate,
+			"status":        sample.Internal.Status.Name,
+			"phenotypes":    flattenEntityRefs(sample.Phenotypes),
+		}
+	}
+
+	d.SetId(computeSearchDataSourceId(params))
+	d.Set("ids", ids)
+	if err := d.Set("samples", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func computeSearchDataSourceId(params map[string]string) string {
+	// Create unique string representing the search parameters
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var id strings.Builder
+	for _, k := range keys {
+		id.WriteString(k)
+		id.WriteRune('=')
+		id.WriteString(params[k])
+		id.WriteRune('|')
+	}
+	return id.String()
+}
@@ -0,0 +1,151 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func dataSourceServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the version and health of the OpenCGA server, e.g. in preconditions " +
+			"that stop an apply against an unsupported or degraded installation",
+		ReadContext: dataSourceServerRead,
+		Schema: map[string]*schema.Schema{
+			// Computed values
+			"version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"git_commit": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"git_branch": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"about": &schema.Schema{
+				Description: "Every field returned by meta/about",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"status": &schema.Schema{
+				Description: "Status of each server component, e.g. `{ CatalogMongoDB = \"OK\", Solr = \"OK\", VariantStorage = \"OK\" }`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"healthy": &schema.Schema{
+				Description: "True when every component reports OK",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"user": &schema.Schema{
+				Description: "The user the provider is logged in as",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	about, err := getServerMeta(client, "meta/about")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	status, err := getServerMeta(client, "meta/status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	healthy := len(status) > 0
+	for _, v := range status {
+		if v != "OK" {
+			healthy = false
+		}
+	}
+
+	path := fmt.Sprintf("users/%s/info", client.Username)
+	params := map[string]string{
+		"include": "id,name,email,account",
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find user %s, got %d results", client.Username, len(resp.Results))
+	}
+	var user User
+	err = mapstructure.Decode(resp.Results[0], &user)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(client.BaseUrl)
+	d.Set("version", about["Version"])
+	d.Set("git_commit", about["Git commit"])
+	d.Set("git_branch", about["Git branch"])
+	d.Set("about", about)
+	d.Set("status", status)
+	d.Set("healthy", healthy)
+	d.Set("user", []interface{}{
+		map[string]interface{}{
+			"id":           user.Id,
+			"name":         user.Name,
+			"email":        user.Email,
+			"account_type": user.Account.Type,
+		},
+	})
+	return diags
+}
+
+func getServerMeta(client *APIClient, path string) (map[string]interface{}, error) {
+	// The meta endpoints return a single map of string values
+	req, err := buildRequest(client, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, fmt.Errorf("Failed to read %s, got %d results", path, len(resp.Results))
+	}
+	result, _ := resp.Results[0].(map[string]interface{})
+	return flattenAnnotations(result), nil
+}
@@ -91,17 +91,13 @@ func dataSourceStudiesRead(ctx context.Context, d *schema.ResourceData, m interf
 		params["name"] = v.(string)
 	}
 
-	req, err := buildRequest(client, path, nil, params)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	resp, err := client.Call(req)
+	results, err := client.Search(path, params, 0)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	studies := make([]Study, len(resp.Results))
-	err = mapstructure.Decode(resp.Results, &studies)
+	studies := make([]Study, len(results))
+	err = mapstructure.Decode(results, &studies)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,224 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func dataSourceStudy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get a single Study, with its groups and ACLs, for use in other resources",
+		ReadContext: dataSourceStudyRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study id, alias or fully qualified name, e.g. `user@project:study`",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"project": &schema.Schema{
+				Description: "A project id or alias to limit the search when looking up the study by alias. " +
+					"When not set it is the project of the fully qualified name of the study",
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			// Computed values
+			"id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"alias": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"fqn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"creation_date": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"users": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"acl": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"member": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"permissions": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"variable_sets": &schema.Schema{
+				Description: "Ids of the variable sets of the study",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"attributes": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceStudyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	params := map[string]string{
+		"include": "id,name,alias,fqn,description,type,creationDate,groups,variableSets.id,attributes",
+	}
+
+	var path string
+	study := d.Get("study").(string)
+	if _, err := strconv.Atoi(study); err == nil || strings.Contains(study, ":") {
+		// Ids and fully qualified names are unique
+		path = fmt.Sprintf("studies/%s/info", study)
+	} else {
+		// Aliases are only unique within a project
+		path = "studies/search"
+		params["alias"] = study
+		if v, ok := d.GetOk("project"); ok {
+			params["project"] = v.(string)
+		}
+	}
+
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) == 0 {
+		return diag.Errorf("Study '%s' not found", study)
+	}
+
+	studies := make([]Study, len(resp.Results))
+	err = mapstructure.Decode(resp.Results, &studies)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(studies) > 1 {
+		matches := make([]string, len(studies))
+		for i, s := range studies {
+			matches[i] = s.Fqn
+		}
+		return diag.Errorf("Study '%s' is ambiguous, use the project or a fully qualified name. Found: %s", study, strings.Join(matches, ", "))
+	}
+
+	acls, err := getStudyACLs(client, strconv.Itoa(studies[0].Id))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(studies[0].Id))
+
+	d.Set("id", studies[0].Id)
+	d.Set("name", studies[0].Name)
+	d.Set("alias", studies[0].Alias)
+	d.Set("fqn", studies[0].Fqn)
+	if _, ok := d.GetOk("project"); !ok {
+		d.Set("project", projectFromFqn(studies[0].Fqn))
+	}
+	d.Set("description", studies[0].Description)
+	d.Set("type", studies[0].Type)
+	d.Set("creation_date", studies[0].CreationDate)
+	d.Set("groups", flattenStudyGroups(studies[0].Groups))
+	d.Set("acl", flattenStudyACLs(acls))
+	d.Set("variable_sets", flattenVariableSetIds(studies[0].VariableSets))
+	d.Set("attributes", flattenAnnotations(studies[0].Attributes))
+	return diags
+}
+
+func flattenStudyGroups(groups []StudyGroup) []interface{} {
+	result := make([]interface{}, len(groups))
+	for i, group := range groups {
+		// Older OpenCGA versions only return the group name
+		id := group.Id
+		if id == "" {
+			id = group.Name
+		}
+		result[i] = map[string]interface{}{
+			"id":    id,
+			"users": group.UserIds,
+		}
+	}
+	return result
+}
+
+func flattenStudyACLs(acls []StudyACL) []interface{} {
+	result := make([]interface{}, len(acls))
+	for i, acl := range acls {
+		result[i] = map[string]interface{}{
+			"member":      acl.Member,
+			"permissions": acl.Permissions,
+		}
+	}
+	return result
+}
+
+func flattenVariableSetIds(variableSets []VariableSet) []int {
+	result := make([]int, len(variableSets))
+	for i, variableSet := range variableSets {
+		result[i] = variableSet.Id
+	}
+	return result
+}
+
+func projectFromFqn(fqn string) string {
+	// Fully qualified names have the form user@project:study
+	project := fqn[strings.Index(fqn, "@")+1:]
+	if i := strings.Index(project, ":"); i >= 0 {
+		project = project[:i]
+	}
+	return project
+}
@@ -0,0 +1,110 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func dataSourceStudyGroups() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the groups of a study and their members",
+		ReadContext: dataSourceStudyGroupsRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study the groups belong to",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"id_filter": &schema.Schema{
+				Description: "Group ids to limit the list, e.g. `@members`. The read fails if any of them does not exist",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			// Computed values
+			"groups": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"users": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"authentication_origin": &schema.Schema{
+							Description: "Authentication origin the members are synced from",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"remote_group": &schema.Schema{
+							Description: "Group in the authentication origin the members are synced from",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStudyGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	// The groups endpoint returns every group at once
+	path := fmt.Sprintf("studies/%s/groups", d.Get("study"))
+	req, err := buildRequest(client, path, nil, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var studyGroups []StudyGroup
+	err = mapstructure.Decode(resp.Results, &studyGroups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	requested := make([]string, 0)
+	for _, id := range d.Get("id_filter").([]interface{}) {
+		requested = append(requested, id.(string))
+	}
+	found := make([]string, 0, len(studyGroups))
+	groups := make([]interface{}, 0, len(studyGroups))
+	for i, group := range flattenStudyGroups(studyGroups) {
+		g := group.(map[string]interface{})
+		found = append(found, g["id"].(string))
+		if len(requested) > 0 && !stringInSlice(g["id"].(string), requested) {
+			continue
+		}
+		g["authentication_origin"] = studyGroups[i].SyncedFrom.AuthOrigin
+		g["remote_group"] = studyGroups[i].SyncedFrom.RemoteGroup
+		groups = append(groups, g)
+	}
+
+	// Fail here rather than when the missing groups are used in an ACL
+	if missing := missingStrings(requested, found); len(missing) > 0 {
+		return diag.Errorf("Groups not found in study %s: %s", d.Get("study"), strings.Join(missing, ", "))
+	}
+
+	d.SetId(fmt.Sprintf("%s|%s", d.Get("study"), strings.Join(requested, ",")))
+	if err := d.Set("groups", groups); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,178 @@
+package opencga
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Search endpoint counted for each entity total
+var study_stats_counts = map[string]string{
+	"samples":     "samples/search",
+	"individuals": "individuals/search",
+	"families":    "families/search",
+	"cohorts":     "cohorts/search",
+	"files":       "files/search",
+}
+
+func dataSourceStudyStats() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get counts of the entities of a study, e.g. in `check` blocks that gate a release",
+		ReadContext: dataSourceStudyStatsRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study to count",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"count_variants": &schema.Schema{
+				Description: "Also count the variants indexed in the study, this can be slow on large studies",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			// Computed values
+			"samples": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"individuals": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"families": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"cohorts": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"files": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"files_by_format": &schema.Schema{
+				Description: "Number of files of each format, e.g. `{ VCF = 10, CRAM = 10 }`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"files_by_index_status": &schema.Schema{
+				Description: "Number of variant files with each variant index status, e.g. `{ READY = 9, NONE = 1 }`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"alignment_files_without_samples": &schema.Schema{
+				Description: "Ids of BAM and CRAM files that are not associated with any sample",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"variants": &schema.Schema{
+				Description: "Number of variants indexed in the study, only set when `count_variants` is true",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceStudyStatsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	study := d.Get("study").(string)
+	params := map[string]string{
+		"study": study,
+	}
+
+	for attribute, path := range study_stats_counts {
+		count, err := countSearch(client, path, params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set(attribute, count)
+	}
+
+	byFormat, err := getAggregation(client, "files/aggregationStats", "format", params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	byIndexStatus, err := getAggregation(client, "files/aggregationStats", "internal.variant.index.status.name", map[string]string{
+		"study":     study,
+		"bioformat": "VARIANT",
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Files without samples can not be searched for, so check each one
+	results, err := client.Search("files/search", map[string]string{
+		"study":   study,
+		"format":  "BAM,CRAM",
+		"include": "id,sampleIds",
+	}, 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	withoutSamples := make([]string, 0)
+	for _, r := range results {
+		file, _ := r.(map[string]interface{})
+		if samples, _ := file["sampleIds"].([]interface{}); len(samples) == 0 {
+			withoutSamples = append(withoutSamples, flattenAnnotationValue(file["id"]))
+		}
+	}
+
+	if d.Get("count_variants").(bool) {
+		count, err := countSearch(client, "analysis/variant/query", params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("variants", count)
+	}
+
+	d.SetId(study)
+	d.Set("files_by_format", byFormat)
+	d.Set("files_by_index_status", byIndexStatus)
+	d.Set("alignment_files_without_samples", withoutSamples)
+	return diags
+}
+
+func getAggregation(client *APIClient, path string, field string, filters map[string]string) (map[string]int, error) {
+	// Count the entities matching the filters for each value of a field
+	params := map[string]string{
+		"field": field,
+	}
+	for k, v := range filters {
+		params[k] = v
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	var facets []FacetField
+	err = mapstructure.Decode(resp.Results, &facets)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, facet := range facets {
+		if facet.Name != field {
+			continue
+		}
+		for _, bucket := range facet.Buckets {
+			counts[bucket.Value] = bucket.Count
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,162 @@
+package opencga
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to search the OpenCGA users, e.g. to check that the members of a group exist",
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"id_filter": &schema.Schema{
+				Description: "User ids to limit the search, the read fails if any of them does not exist",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"organization": &schema.Schema{
+				Description: "Only return users of this organization",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"account_type": &schema.Schema{
+				Description:  "Account type to limit the search, can be one of: GUEST, FULL, ADMINISTRATOR",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"GUEST", "FULL", "ADMINISTRATOR"}, false),
+			},
+			"authentication_origin": &schema.Schema{
+				Description: "Authentication origin id to limit the search, e.g. `internal` or the id of an LDAP origin",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			// Computed values
+			"ids": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"users": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"organization": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"account_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"authentication_origin": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	params := map[string]string{
+		"include": "id,name,email,organization,account,internal.status",
+	}
+	requested := make([]string, 0)
+	if v, ok := d.GetOk("id_filter"); ok {
+		for _, id := range v.([]interface{}) {
+			requested = append(requested, id.(string))
+		}
+		params["user"] = strings.Join(requested, ",")
+	}
+	if v, ok := d.GetOk("account_type"); ok {
+		params["account"] = v.(string)
+	}
+	if v, ok := d.GetOk("authentication_origin"); ok {
+		params["authenticationId"] = v.(string)
+	}
+
+	results, err := client.Search("admin/users/search", params, 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var users []User
+	err = mapstructure.Decode(results, &users)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found := make([]string, 0, len(users))
+	ids := make([]string, 0, len(users))
+	flattened := make([]interface{}, 0, len(users))
+	for _, user := range users {
+		found = append(found, user.Id)
+		// The search endpoint has no organization filter
+		if v, ok := d.GetOk("organization"); ok && user.Organization != v.(string) {
+			continue
+		}
+		ids = append(ids, user.Id)
+		flattened = append(flattened, map[string]interface{}{
+			"id":                    user.Id,
+			"name":                  user.Name,
+			"email":                 user.Email,
+			"organization":          user.Organization,
+			"account_type":          user.Account.Type,
+			"authentication_origin": user.Account.Authentication.Id,
+			"status":                user.Internal.Status.Name,
+		})
+	}
+
+	// Fail here rather than when the missing users are added to a group
+	if missing := missingStrings(requested, found); len(missing) > 0 {
+		return diag.Errorf("Users not found: %s", strings.Join(missing, ", "))
+	}
+
+	d.SetId(computeSearchDataSourceId(params) + d.Get("organization").(string))
+	d.Set("ids", ids)
+	if err := d.Set("users", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func missingStrings(requested []string, found []string) []string {
+	// Requested values that are not in found
+	missing := make([]string, 0)
+	for _, v := range requested {
+		if !stringInSlice(v, found) {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
@@ -66,17 +66,13 @@ func dataSourceVariableSetsRead(ctx context.Context, d *schema.ResourceData, m i
 		"exclude": "variables",
 	}
 
-	req, err := buildRequest(client, path, nil, params)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	resp, err := client.Call(req)
+	results, err := client.Search(path, params, 0)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	variable_sets := make([]VariableSet, len(resp.Results))
-	err = mapstructure.Decode(resp.Results, &variable_sets)
+	variable_sets := make([]VariableSet, len(results))
+	err = mapstructure.Decode(results, &variable_sets)
 	if err != nil {
 		return diag.FromErr(err)
 	}
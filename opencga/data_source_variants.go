@@ -0,0 +1,200 @@
+package opencga
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Query parameter used by each variant filter
+var variant_query_filters = map[string]string{
+	"variant_id":       "id",
+	"region":           "region",
+	"gene":             "gene",
+	"sample":           "sample",
+	"file":             "file",
+	"type":             "type",
+	"consequence_type": "ct",
+}
+
+func dataSourceVariants() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to count the variants of a study matching a query, e.g. in `check` blocks " +
+			"asserting that a truth set sample is fully indexed. Only a small page of the matching variants is returned",
+		ReadContext: dataSourceVariantsRead,
+		Schema: map[string]*schema.Schema{
+			// Filter values
+			"study": &schema.Schema{
+				Description: "The study to query",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"variant_id": &schema.Schema{
+				Description: "Comma separated variant ids, e.g. `1:10000:A:T`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"region": &schema.Schema{
+				Description: "Comma separated regions, e.g. `1:10000-20000,2`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"gene": &schema.Schema{
+				Description: "Comma separated gene names or ids",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"sample": &schema.Schema{
+				Description: "Comma separated sample ids, optionally with genotypes, e.g. `NA12877:0/1,1/1`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"file": &schema.Schema{
+				Description: "Comma separated ids or names of the files the variants were loaded from",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": &schema.Schema{
+				Description: "Comma separated variant types, e.g. SNV,INDEL",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"consequence_type": &schema.Schema{
+				Description: "Comma separated consequence types, e.g. missense_variant",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"limit": &schema.Schema{
+				Description:  "Number of variants to return, between 0 and 1000. Use 0 to only count the variants",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(0, 1000),
+			},
+			"facet": &schema.Schema{
+				Description: "Also count the matching variants for each value of this field, e.g. `type` or `chromosome`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			// Computed values
+			"total": &schema.Schema{
+				Description: "Number of variants matching the query",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"facet_counts": &schema.Schema{
+				Description: "Number of matching variants for each value of `facet`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"variants": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"chromosome": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"end": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"reference": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"alternate": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVariantsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	query := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	for attribute, param := range variant_query_filters {
+		if v, ok := d.GetOk(attribute); ok {
+			query[param] = v.(string)
+		}
+	}
+
+	// Variant results can be large so only a single page is requested,
+	// the count covers every matching variant
+	params := map[string]string{
+		"limit":   strconv.Itoa(d.Get("limit").(int)),
+		"count":   "true",
+		"exclude": "annotation,studies",
+	}
+	for k, v := range query {
+		params[k] = v
+	}
+	req, err := buildRequest(client, "analysis/variant/query", nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var variants []Variant
+	err = mapstructure.Decode(resp.Results, &variants)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, len(variants))
+	for i, variant := range variants {
+		flattened[i] = map[string]interface{}{
+			"id":         variant.Id,
+			"chromosome": variant.Chromosome,
+			"start":      variant.Start,
+			"end":        variant.End,
+			"reference":  variant.Reference,
+			"alternate":  variant.Alternate,
+			"type":       variant.Type,
+		}
+	}
+
+	if v, ok := d.GetOk("facet"); ok {
+		counts, err := getAggregation(client, "analysis/variant/aggregationStats", v.(string), query)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("facet_counts", counts)
+	}
+
+	d.SetId(computeSearchDataSourceId(params) + d.Get("facet").(string))
+	d.Set("total", resp.NumTotalResults)
+	if err := d.Set("variants", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
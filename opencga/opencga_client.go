@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
+	"strconv"
 	"sync"
 
 	"github.com/mitchellh/mapstructure"
@@ -24,21 +27,29 @@ It uses the type structs from opencga_types.go to return data to the caller.
 
 type APIClient struct {
 	BaseUrl    string
+	Username   string
 	Token      string
 	HttpClient *http.Client
 	Mutex      sync.Mutex // Used on API calls that are not thread safe
+	PageSize   int        // Number of results requested per page by Search
+	MaxResults int        // Largest search that Search will return, 0 for no limit
 }
 
 func newClient(baseUrl string) *APIClient {
 	c := &APIClient{}
 	c.BaseUrl = baseUrl
 	c.HttpClient = http.DefaultClient
+	c.PageSize = 100
 	log.Printf("created api client for: %s\n", c.BaseUrl)
 	return c
 }
 
+func buildUrl(client *APIClient, path string) string {
+	return fmt.Sprintf("%s/opencga/webservices/rest/v1/%s", client.BaseUrl, path)
+}
+
 func buildRequest(client *APIClient, path string, body interface{}, params map[string]string) (*http.Request, error) {
-	url := fmt.Sprintf("%s/opencga/webservices/rest/v1/%s", client.BaseUrl, path)
+	url := buildUrl(client, path)
 
 	var req *http.Request
 	var err error
@@ -60,6 +71,39 @@ func buildRequest(client *APIClient, path string, body interface{}, params map[s
 	return req, nil
 }
 
+func buildUploadRequest(client *APIClient, path string, fields map[string]string, filename string, content io.Reader, params map[string]string) (*http.Request, error) {
+	// Stream the multipart body so large files are not held in memory
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		var err error
+		for key, val := range fields {
+			if err = form.WriteField(key, val); err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+		part, err := form.CreateFormFile("file", filename)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequest("POST", buildUrl(client, path), reader)
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	buildQuery(client, req, params)
+	return req, nil
+}
+
 func buildQuery(client *APIClient, req *http.Request, params map[string]string) {
 	q := req.URL.Query()
 	if client.Token != "" {
@@ -71,6 +115,61 @@ func buildQuery(client *APIClient, req *http.Request, params map[string]string)
 	req.URL.RawQuery = q.Encode()
 }
 
+func (c *APIClient) Search(path string, params map[string]string, maxResults int) ([]interface{}, error) {
+	// Page through the results of a search endpoint using limit and skip.
+	// A maxResults of 0 returns every result, as long as there are no more
+	// than the client MaxResults.
+	results := make([]interface{}, 0)
+	total := -1
+	for maxResults == 0 || len(results) < maxResults {
+		limit := c.PageSize
+		if maxResults > 0 && maxResults-len(results) < limit {
+			limit = maxResults - len(results)
+		}
+		page := map[string]string{
+			"limit": strconv.Itoa(limit),
+			"skip":  strconv.Itoa(len(results)),
+		}
+		if total < 0 {
+			page["count"] = "true"
+		}
+		for key, val := range params {
+			// Paging is controlled here, not by the caller
+			if key == "limit" || key == "skip" {
+				continue
+			}
+			page[key] = val
+		}
+		req, err := buildRequest(c, path, nil, page)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.Call(req)
+		if err != nil {
+			return nil, err
+		}
+		if total < 0 {
+			total = resp.NumTotalResults
+			if maxResults == 0 && c.MaxResults > 0 && total > c.MaxResults {
+				return nil, fmt.Errorf("Search of %s matches %d results, more than the maximum of %d", path, total, c.MaxResults)
+			}
+		}
+		results = append(results, resp.Results...)
+		// Stop on the total as well as a short page, in case the endpoint
+		// ignores skip
+		if len(resp.Results) < limit || (total >= 0 && len(results) >= total) {
+			break
+		}
+	}
+
+	// Results are only expected to be short of the total when limited by
+	// maxResults, otherwise the data changed while paging
+	if (maxResults == 0 || len(results) < maxResults) && total >= 0 && len(results) != total {
+		return nil, fmt.Errorf("Search of %s returned %d results, expected %d", path, len(results), total)
+	}
+	return results, nil
+}
+
 func (c *APIClient) Login(user string, password string) error {
 	path := fmt.Sprintf("users/%s/login", user)
 	body := map[string]string{
@@ -91,11 +190,24 @@ func (c *APIClient) Login(user string, password string) error {
 		return err
 	}
 
+	c.Username = user
 	c.Token = login.Token
 	return nil
 }
 
 func (c *APIClient) Call(req *http.Request) (*Response, error) {
+	responses, err := c.CallAll(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) != 1 {
+		return nil, fmt.Errorf("API Error: expecting 1 response, got %d", len(responses))
+	}
+	return &responses[0], nil
+}
+
+func (c *APIClient) CallAll(req *http.Request) ([]Response, error) {
+	// Requests for several comma separated ids get one response per id
 	log.Printf("calling: %s %s", req.Method, req.URL)
 	resp, err := c.HttpClient.Do(req)
 	if err != nil {
@@ -124,17 +236,13 @@ func (c *APIClient) Call(req *http.Request) (*Response, error) {
 
 	// Check response for errors
 	if api_response.Error != "" {
-		err = fmt.Errorf("API Error: %s", api_response.Error)
+		return nil, fmt.Errorf("API Error: %s", api_response.Error)
 	}
-	if len(api_response.Responses) != 1 {
-		err = fmt.Errorf("API Error: expecting 1 response, got %d", len(api_response.Responses))
-	}
-	if api_response.Responses[0].ErrorMsg != "" {
-		err = fmt.Errorf("API Error: %s", api_response.Responses[0].ErrorMsg)
-	}
-	if err != nil {
-		return nil, err
+	for _, response := range api_response.Responses {
+		if response.ErrorMsg != "" {
+			return nil, fmt.Errorf("API Error: %s", response.ErrorMsg)
+		}
 	}
 
-	return &api_response.Responses[0], nil
+	return api_response.Responses, nil
 }
@@ -0,0 +1,148 @@
+package opencga
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// Serve a search endpoint with total results, optionally ignoring skip like
+// some OpenCGA endpoints do
+func newSearchServer(t *testing.T, total int, ignoreSkip bool, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if *calls > 100 {
+			t.Errorf("too many requests to %s", r.URL.Path)
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		q := r.URL.Query()
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		skip, _ := strconv.Atoi(q.Get("skip"))
+		if ignoreSkip {
+			skip = 0
+		}
+		results := make([]interface{}, 0)
+		for i := skip; i < total && i < skip+limit; i++ {
+			results = append(results, map[string]interface{}{"id": strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"response": []map[string]interface{}{
+				{
+					"numResults":      len(results),
+					"numTotalResults": total,
+					"result":          results,
+				},
+			},
+		})
+	}))
+}
+
+func newSearchClient(url string) *APIClient {
+	client := newClient(url)
+	client.PageSize = 100
+	return client
+}
+
+func TestSearchPagesAllResults(t *testing.T) {
+	calls := 0
+	server := newSearchServer(t, 250, false, &calls)
+	defer server.Close()
+
+	results, err := newSearchClient(server.URL).Search("samples/search", map[string]string{"study": "s"}, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(results) != 250 {
+		t.Fatalf("expected 250 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if id := r.(map[string]interface{})["id"]; id != strconv.Itoa(i) {
+			t.Fatalf("expected result %d to have id %d, got %v", i, i, id)
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 requests, got %d", calls)
+	}
+}
+
+func TestSearchExactPages(t *testing.T) {
+	calls := 0
+	server := newSearchServer(t, 200, false, &calls)
+	defer server.Close()
+
+	results, err := newSearchClient(server.URL).Search("samples/search", nil, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(results) != 200 {
+		t.Fatalf("expected 200 results, got %d", len(results))
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestSearchMaxResults(t *testing.T) {
+	calls := 0
+	server := newSearchServer(t, 250, false, &calls)
+	defer server.Close()
+
+	results, err := newSearchClient(server.URL).Search("samples/search", nil, 150)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(results) != 150 {
+		t.Fatalf("expected 150 results, got %d", len(results))
+	}
+}
+
+func TestSearchIgnoresCallerPaging(t *testing.T) {
+	calls := 0
+	server := newSearchServer(t, 250, false, &calls)
+	defer server.Close()
+
+	params := map[string]string{"limit": "1", "skip": "10"}
+	results, err := newSearchClient(server.URL).Search("samples/search", params, 0)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(results) != 250 {
+		t.Fatalf("expected 250 results, got %d", len(results))
+	}
+	if id := results[0].(map[string]interface{})["id"]; id != "0" {
+		t.Fatalf("expected the first result to have id 0, got %v", id)
+	}
+}
+
+func TestSearchStopsWhenSkipIsIgnored(t *testing.T) {
+	calls := 0
+	server := newSearchServer(t, 250, true, &calls)
+	defer server.Close()
+
+	_, err := newSearchClient(server.URL).Search("samples/search", nil, 0)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 requests, got %d", calls)
+	}
+}
+
+func TestSearchClientMaxResults(t *testing.T) {
+	calls := 0
+	server := newSearchServer(t, 250, false, &calls)
+	defer server.Close()
+
+	client := newSearchClient(server.URL)
+	client.MaxResults = 200
+	_, err := client.Search("samples/search", nil, 0)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 request, got %d", calls)
+	}
+}
@@ -3,16 +3,30 @@ package opencga
 // This module contains structs to represent the data returned from OpenCGA API calls
 
 /*
-File represents a catalog entry with meta data on a file in a mounted filesystem
+File represents a catalog entry with meta data on a file in a mounted filesystem,
+including the status of its variant index
 */
+type FileIndex struct {
+	Status InternalStatus `mapstructure:"status"`
+}
+type FileVariantInternal struct {
+	Index FileIndex `mapstructure:"index"`
+}
+type FileInternal struct {
+	Variant FileVariantInternal `mapstructure:"variant"`
+}
 type File struct {
-	Id        int    `mapstructure:"id"`
-	Name      string `mapstructure:"name"`
-	Type      string `mapstructure:"type"`
-	Format    string `mapstructure:"format"`
-	Bioformat string `mapstructure:"bioformat"`
-	Uri       string `mapstructure:"uri"`
-	Path      string `mapstructure:"path"`
+	Id        int          `mapstructure:"id"`
+	Name      string       `mapstructure:"name"`
+	Type      string       `mapstructure:"type"`
+	Format    string       `mapstructure:"format"`
+	Bioformat string       `mapstructure:"bioformat"`
+	Uri       string       `mapstructure:"uri"`
+	Path      string       `mapstructure:"path"`
+	External  bool         `mapstructure:"external"`
+	Size      int          `mapstructure:"size"`
+	Checksum  string       `mapstructure:"checksum"`
+	Internal  FileInternal `mapstructure:"internal"`
 }
 
 /*
@@ -27,12 +41,29 @@ type Organism struct {
 	TaxonomyCode   int    `mapstructure:"taxonomyCode"`
 	Assembly       string `mapstructure:"assembly"`
 }
+type CellBaseConfiguration struct {
+	Url         string `mapstructure:"url"`
+	Version     string `mapstructure:"version"`
+	DataRelease string `mapstructure:"dataRelease"`
+}
+type VariantDatastore struct {
+	StorageEngine string                 `mapstructure:"storageEngine"`
+	Options       map[string]interface{} `mapstructure:"options"`
+}
+type ProjectDatastores struct {
+	Variant VariantDatastore `mapstructure:"variant"`
+}
+type ProjectInternal struct {
+	Datastores ProjectDatastores `mapstructure:"datastores"`
+}
 type Project struct {
-	Id          int      `mapstructure:"id"`
-	Name        string   `mapstructure:"name"`
-	Description string   `mapstructure:"description"`
-	Alias       string   `mapstructure:"alias"`
-	Organism    Organism `mapstructure:"organism"`
+	Id          int                   `mapstructure:"id"`
+	Name        string                `mapstructure:"name"`
+	Description string                `mapstructure:"description"`
+	Alias       string                `mapstructure:"alias"`
+	Organism    Organism              `mapstructure:"organism"`
+	Cellbase    CellBaseConfiguration `mapstructure:"cellbase"`
+	Internal    ProjectInternal       `mapstructure:"internal"`
 }
 
 /*
@@ -42,11 +73,64 @@ This is not an exhaustive set of attributes that make up the opencga study
 data type. Add more as and when they are needed by the provider.
 */
 type Study struct {
-	Id          int    `mapstructure:"id"`
+	Id           int                    `mapstructure:"id"`
+	Name         string                 `mapstructure:"name"`
+	Alias        string                 `mapstructure:"alias"`
+	Fqn          string                 `mapstructure:"fqn"`
+	Description  string                 `mapstructure:"description"`
+	Type         string                 `mapstructure:"type"`
+	CreationDate string                 `mapstructure:"creationDate"`
+	Groups       []StudyGroup           `mapstructure:"groups"`
+	VariableSets []VariableSet          `mapstructure:"variableSets"`
+	Attributes   map[string]interface{} `mapstructure:"attributes"`
+	Internal     StudyInternal          `mapstructure:"internal"`
+}
+
+/*
+The clinical configuration of a study sets the statuses, flags and priorities
+that can be used by its clinical analyses. Statuses and flags are defined per
+clinical analysis type, e.g. SINGLE, FAMILY.
+*/
+type ClinicalStatusValue struct {
+	Id          string `mapstructure:"id"`
+	Description string `mapstructure:"description"`
+	Type        string `mapstructure:"type"`
+}
+type ClinicalFlagValue struct {
+	Id          string `mapstructure:"id"`
+	Description string `mapstructure:"description"`
+}
+type ClinicalPriorityValue struct {
+	Id              string `mapstructure:"id"`
+	Description     string `mapstructure:"description"`
+	Rank            int    `mapstructure:"rank"`
+	DefaultPriority bool   `mapstructure:"defaultPriority"`
+}
+type ClinicalConsent struct {
+	Id          string `mapstructure:"id"`
 	Name        string `mapstructure:"name"`
-	Alias       string `mapstructure:"alias"`
 	Description string `mapstructure:"description"`
 }
+type ClinicalConsentConfiguration struct {
+	Consents []ClinicalConsent `mapstructure:"consents"`
+}
+type InterpretationConfiguration struct {
+	Status        map[string][]ClinicalStatusValue `mapstructure:"status"`
+	DefaultFilter map[string]interface{}           `mapstructure:"defaultFilter"`
+}
+type ClinicalConfiguration struct {
+	Status         map[string][]ClinicalStatusValue `mapstructure:"status"`
+	Interpretation InterpretationConfiguration      `mapstructure:"interpretation"`
+	Priorities     []ClinicalPriorityValue          `mapstructure:"priorities"`
+	Flags          map[string][]ClinicalFlagValue   `mapstructure:"flags"`
+	Consent        ClinicalConsentConfiguration     `mapstructure:"consent"`
+}
+type StudyConfiguration struct {
+	Clinical ClinicalConfiguration `mapstructure:"clinical"`
+}
+type StudyInternal struct {
+	Configuration StudyConfiguration `mapstructure:"configuration"`
+}
 
 /*
 To manage user and group permissions for a study we use the ACL
@@ -57,13 +141,30 @@ type StudyACL struct {
 	Permissions []string `mapstructure:"permissions"`
 }
 
+/*
+PermissionRule applies permissions to every entity of a study matching a query,
+including entities created after the rule
+*/
+type PermissionRule struct {
+	Id          string                 `mapstructure:"id"`
+	Query       map[string]interface{} `mapstructure:"query"`
+	Members     []string               `mapstructure:"members"`
+	Permissions []string               `mapstructure:"permissions"`
+}
+
 /*
 User groups configured for a study, eg @members or @admins
-To be used for creating AD groups
+Groups may be synchronised from an AD/LDAP group of an authentication origin
 */
+type GroupSync struct {
+	AuthOrigin  string `mapstructure:"authOrigin"`
+	RemoteGroup string `mapstructure:"remoteGroup"`
+}
 type StudyGroup struct {
-	Id   string `mapstructure:"id"`
-	Name string `mapstructure:"name"`
+	Id         string    `mapstructure:"id"`
+	Name       string    `mapstructure:"name"`
+	UserIds    []string  `mapstructure:"userIds"`
+	SyncedFrom GroupSync `mapstructure:"syncedFrom"`
 }
 
 /*
@@ -78,6 +179,164 @@ type VariableSet struct {
 	Variables   []interface{} `mapstructure:"variables"`
 }
 
+/*
+EntityRef is a reference to another catalog entity by its id, as used in
+nested fields such as the proband or panels of a clinical analysis.
+*/
+type EntityRef struct {
+	Id string `mapstructure:"id"`
+}
+
+/*
+ClinicalAnalysis represents a clinical case for a proband or family.
+
+Interpretations are created by analysis tools after the case exists, so
+are only ever read back by the provider.
+*/
+type ClinicalAnalysis struct {
+	Id                       string      `mapstructure:"id"`
+	Description              string      `mapstructure:"description"`
+	Type                     string      `mapstructure:"type"`
+	Proband                  EntityRef   `mapstructure:"proband"`
+	Family                   EntityRef   `mapstructure:"family"`
+	Disorder                 EntityRef   `mapstructure:"disorder"`
+	Panels                   []EntityRef `mapstructure:"panels"`
+	Analysts                 []EntityRef `mapstructure:"analysts"`
+	Priority                 EntityRef   `mapstructure:"priority"`
+	Flags                    []EntityRef `mapstructure:"flags"`
+	DueDate                  string      `mapstructure:"dueDate"`
+	Status                   EntityRef   `mapstructure:"status"`
+	Interpretation           EntityRef   `mapstructure:"interpretation"`
+	SecondaryInterpretations []EntityRef `mapstructure:"secondaryInterpretations"`
+}
+
+/*
+User represents an OpenCGA account, either local or imported from an
+external authentication origin such as LDAP.
+*/
+type UserAccount struct {
+	Type           string    `mapstructure:"type"`
+	Authentication EntityRef `mapstructure:"authentication"`
+}
+type UserQuota struct {
+	MaxDisk int `mapstructure:"maxDisk"`
+	MaxCpu  int `mapstructure:"maxCpu"`
+}
+type InternalStatus struct {
+	Name string `mapstructure:"name"`
+}
+type UserInternal struct {
+	Status InternalStatus `mapstructure:"status"`
+}
+type User struct {
+	Id           string       `mapstructure:"id"`
+	Name         string       `mapstructure:"name"`
+	Email        string       `mapstructure:"email"`
+	Organization string       `mapstructure:"organization"`
+	Account      UserAccount  `mapstructure:"account"`
+	Quota        UserQuota    `mapstructure:"quota"`
+	Internal     UserInternal `mapstructure:"internal"`
+}
+
+/*
+AnnotationSet holds the values of a VariableSet attached to a sample,
+individual, family, cohort or file.
+*/
+type AnnotationSet struct {
+	Id            string                 `mapstructure:"id"`
+	VariableSetId int                    `mapstructure:"variableSetId"`
+	Annotations   map[string]interface{} `mapstructure:"annotations"`
+}
+
+/*
+AnnotatedEntity is the subset of an annotatable entity needed to find its
+annotation sets.
+*/
+type AnnotatedEntity struct {
+	AnnotationSets []AnnotationSet `mapstructure:"annotationSets"`
+}
+
+/*
+Sample represents a biological sample, optionally belonging to an individual
+*/
+type SampleInternal struct {
+	Status InternalStatus `mapstructure:"status"`
+}
+type Sample struct {
+	Id           string         `mapstructure:"id"`
+	IndividualId string         `mapstructure:"individualId"`
+	Somatic      bool           `mapstructure:"somatic"`
+	CreationDate string         `mapstructure:"creationDate"`
+	Phenotypes   []EntityRef    `mapstructure:"phenotypes"`
+	Internal     SampleInternal `mapstructure:"internal"`
+}
+
+/*
+Job represents an analysis or operation submitted to OpenCGA. Output files
+are kept as raw maps as only their ids are needed.
+*/
+type JobInternal struct {
+	Status InternalStatus `mapstructure:"status"`
+}
+type Job struct {
+	Id           string                   `mapstructure:"id"`
+	Tool         EntityRef                `mapstructure:"tool"`
+	Study        EntityRef                `mapstructure:"study"`
+	UserId       string                   `mapstructure:"userId"`
+	CreationDate string                   `mapstructure:"creationDate"`
+	Internal     JobInternal              `mapstructure:"internal"`
+	Output       []map[string]interface{} `mapstructure:"output"`
+}
+
+/*
+FileContent is returned when reading the content of a file, such as a job log
+*/
+type FileContent struct {
+	Content string `mapstructure:"content"`
+}
+
+/*
+Variant is a single variant returned by a variant query, without its
+annotation or sample data
+*/
+type Variant struct {
+	Id         string `mapstructure:"id"`
+	Chromosome string `mapstructure:"chromosome"`
+	Start      int    `mapstructure:"start"`
+	End        int    `mapstructure:"end"`
+	Reference  string `mapstructure:"reference"`
+	Alternate  string `mapstructure:"alternate"`
+	Type       string `mapstructure:"type"`
+}
+
+/*
+AuditRecord is an entry of the audit log of changes made through OpenCGA
+*/
+type AuditRecord struct {
+	Id         string         `mapstructure:"id"`
+	UserId     string         `mapstructure:"userId"`
+	Action     string         `mapstructure:"action"`
+	Resource   string         `mapstructure:"resource"`
+	ResourceId string         `mapstructure:"resourceId"`
+	StudyId    string         `mapstructure:"studyId"`
+	Status     InternalStatus `mapstructure:"status"`
+	Date       string         `mapstructure:"date"`
+}
+
+/*
+FacetField is a single field of the results of an aggregation stats query,
+with the number of entities for each value of the field
+*/
+type FacetBucket struct {
+	Value string `mapstructure:"value"`
+	Count int    `mapstructure:"count"`
+}
+type FacetField struct {
+	Name    string        `mapstructure:"name"`
+	Count   int           `mapstructure:"count"`
+	Buckets []FacetBucket `mapstructure:"buckets"`
+}
+
 /*
 Login represents the data returned from a user login request
 */
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 /*
@@ -44,20 +45,59 @@ func Provider() *schema.Provider {
 				Required:    true,
 				Description: "Host URL for OpenCGA REST API, e.g. https://opencga.mycompany.com",
 			},
+			"search_page_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      100,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of results requested per page by data sources that search OpenCGA.",
+			},
+			"search_max_results": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10000,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Data source searches matching more results than this fail rather than paging through them all. Set to 0 for no limit.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"opencga_file":        resourceFile(),
-			"opencga_project":     resourceProject(),
-			"opencga_study":       resourceStudy(),
-			"opencga_study_acl":   resourceStudyACL(),
-			"opencga_study_group": resourceStudyGroup(),
-			"opencga_variableset": resourceVariableSet(),
+			"opencga_annotation_set":               resourceAnnotationSet(),
+			"opencga_clinical_analysis":            resourceClinicalAnalysis(),
+			"opencga_entity_acl":                   resourceEntityACL(),
+			"opencga_file":                         resourceFile(),
+			"opencga_file_upload":                  resourceFileUpload(),
+			"opencga_folder":                       resourceFolder(),
+			"opencga_job":                          resourceJob(),
+			"opencga_permission_rule":              resourcePermissionRule(),
+			"opencga_project":                      resourceProject(),
+			"opencga_study":                        resourceStudy(),
+			"opencga_study_acl":                    resourceStudyACL(),
+			"opencga_study_acl_policy":             resourceStudyACLPolicy(),
+			"opencga_study_clinical_configuration": resourceStudyClinicalConfiguration(),
+			"opencga_study_group":                  resourceStudyGroup(),
+			"opencga_study_group_member":           resourceStudyGroupMember(),
+			"opencga_user":                         resourceUser(),
+			"opencga_variableset":                  resourceVariableSet(),
+			"opencga_variant_engine_configuration": resourceVariantEngineConfiguration(),
+			"opencga_variant_index":                resourceVariantIndex(),
+			"opencga_variant_operation":            resourceVariantOperation(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"opencga_project":      dataSourceProject(),
-			"opencga_projects":     dataSourceProjects(),
-			"opencga_studies":      dataSourceStudies(),
-			"opencga_variablesets": dataSourceVariableSets(),
+			"opencga_audit":                 dataSourceAudit(),
+			"opencga_effective_permissions": dataSourceEffectivePermissions(),
+			"opencga_files":                 dataSourceFiles(),
+			"opencga_jobs":                  dataSourceJobs(),
+			"opencga_project":               dataSourceProject(),
+			"opencga_projects":              dataSourceProjects(),
+			"opencga_samples":               dataSourceSamples(),
+			"opencga_server":                dataSourceServer(),
+			"opencga_studies":               dataSourceStudies(),
+			"opencga_study":                 dataSourceStudy(),
+			"opencga_study_groups":          dataSourceStudyGroups(),
+			"opencga_study_stats":           dataSourceStudyStats(),
+			"opencga_users":                 dataSourceUsers(),
+			"opencga_variablesets":          dataSourceVariableSets(),
+			"opencga_variants":              dataSourceVariants(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -82,6 +122,8 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	}
 
 	client := newClient(base_url)
+	client.PageSize = d.Get("search_page_size").(int)
+	client.MaxResults = d.Get("search_max_results").(int)
 	err := client.Login(username, password)
 	if err != nil {
 		return nil, diag.FromErr(err)
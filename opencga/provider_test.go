@@ -0,0 +1,11 @@
+package opencga
+
+import (
+	"testing"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
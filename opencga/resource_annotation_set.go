@@ -0,0 +1,491 @@
+package opencga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// REST path prefix of each entity type that can be referenced by the provider
+var entity_paths = map[string]string{
+	"sample":     "samples",
+	"individual": "individuals",
+	"family":     "families",
+	"cohort":     "cohorts",
+	"file":       "files",
+	"panel":      "panels",
+	"job":        "jobs",
+}
+
+var annotated_entity_types = []string{"sample", "individual", "family", "cohort", "file"}
+
+func resourceAnnotationSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAnnotationSetCreate,
+		ReadContext:   resourceAnnotationSetRead,
+		UpdateContext: resourceAnnotationSetUpdate,
+		DeleteContext: resourceAnnotationSetDelete,
+		CustomizeDiff: resourceAnnotationSetCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study the annotated entity belongs to.",
+			},
+			"entity_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(annotated_entity_types, false),
+				Description:  "Type of the annotated entity, can be one of: sample, individual, family, cohort, file.",
+			},
+			"entity": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the entity to annotate.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Annotation set id, must be unique for the entity.",
+			},
+			"variable_set": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the variable set that defines the annotations.",
+			},
+			"annotations": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"annotations", "annotations_json"},
+				Description:  "Annotation values keyed by variable name. Values are converted to the variable type, multi value variables are comma separated.",
+			},
+			"annotations_json": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				ExactlyOneOf:     []string{"annotations", "annotations_json"},
+				Description:      "Json object of annotation values keyed by variable name, use this for nested OBJECT or MAP variables.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("study", "entity_type", "entity", "name"),
+		},
+	}
+}
+
+func resourceAnnotationSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	variableSet, err := getVariableSet(client, d.Get("variable_set").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	annotations, err := expandAnnotations(d.Get("annotations").(map[string]interface{}), d.Get("annotations_json").(string), variableSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	payload := map[string]interface{}{
+		"annotationSets": []map[string]interface{}{
+			{
+				"id":            d.Get("name").(string),
+				"variableSetId": d.Get("variable_set").(string),
+				"annotations":   annotations,
+			},
+		},
+	}
+	params := map[string]string{
+		"study":                d.Get("study").(string),
+		"annotationSetsAction": "ADD",
+	}
+	path := fmt.Sprintf("%s/%s/update", entity_paths[d.Get("entity_type").(string)], d.Get("entity"))
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("name").(string))
+	resourceAnnotationSetRead(ctx, d, m)
+	return diags
+}
+
+func resourceAnnotationSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("%s/%s/info", entity_paths[d.Get("entity_type").(string)], d.Get("entity"))
+	params := map[string]string{
+		"study":   d.Get("study").(string),
+		"include": "annotationSets",
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find %s %s, got %d results", d.Get("entity_type"), d.Get("entity"), len(resp.Results))
+	}
+	var entity AnnotatedEntity
+	err = mapstructure.Decode(resp.Results[0], &entity)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var annotationSet *AnnotationSet
+	for i, a := range entity.AnnotationSets {
+		if a.Id == d.Id() {
+			annotationSet = &entity.AnnotationSets[i]
+		}
+	}
+	if annotationSet == nil {
+		return diag.Errorf("Failed to find annotation set %s on %s %s", d.Id(), d.Get("entity_type"), d.Get("entity"))
+	}
+
+	d.Set("name", annotationSet.Id)
+	d.Set("variable_set", strconv.Itoa(annotationSet.VariableSetId))
+	if _, ok := d.GetOk("annotations_json"); ok {
+		annotations_string, err := json.Marshal(annotationSet.Annotations)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("annotations_json", string(annotations_string))
+	} else {
+		d.Set("annotations", flattenAnnotations(annotationSet.Annotations))
+	}
+	return diags
+}
+
+func resourceAnnotationSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	variableSet, err := getVariableSet(client, d.Get("variable_set").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	oldMap, newMap := d.GetChange("annotations")
+	oldJson, newJson := d.GetChange("annotations_json")
+	oldAnnotations, err := expandAnnotations(oldMap.(map[string]interface{}), oldJson.(string), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newAnnotations, err := expandAnnotations(newMap.(map[string]interface{}), newJson.(string), variableSet)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Variables no longer present are removed, the rest are SET which
+	// also adds any new variables
+	removed := make([]string, 0)
+	for k := range oldAnnotations {
+		if _, ok := newAnnotations[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	if len(removed) > 0 {
+		payload := map[string]interface{}{
+			"remove": strings.Join(removed, ","),
+		}
+		if err := updateAnnotations(client, d, "REMOVE", payload); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if len(newAnnotations) > 0 {
+		if err := updateAnnotations(client, d, "SET", newAnnotations); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceAnnotationSetRead(ctx, d, m)
+}
+
+func resourceAnnotationSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	payload := map[string]interface{}{
+		"annotationSets": []map[string]interface{}{
+			{"id": d.Id()},
+		},
+	}
+	params := map[string]string{
+		"study":                d.Get("study").(string),
+		"annotationSetsAction": "REMOVE",
+	}
+	path := fmt.Sprintf("%s/%s/update", entity_paths[d.Get("entity_type").(string)], d.Get("entity"))
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceAnnotationSetCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Validate the annotations against the variable set during plan, this
+	// is skipped when the variable set is created in the same apply
+	if !d.NewValueKnown("variable_set") || !d.NewValueKnown("annotations") || !d.NewValueKnown("annotations_json") {
+		return nil
+	}
+	client := m.(*APIClient)
+	variableSet, err := getVariableSet(client, d.Get("variable_set").(string))
+	if err != nil {
+		return err
+	}
+	_, err = expandAnnotations(d.Get("annotations").(map[string]interface{}), d.Get("annotations_json").(string), variableSet)
+	return err
+}
+
+func importStateIdFunc(attributes ...string) schema.StateContextFunc {
+	// Import resources that are identified by several attributes using
+	// an id of the form attr1/attr2/..., the last part becomes the id
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		parts := strings.Split(d.Id(), "/")
+		if len(parts) != len(attributes) {
+			return nil, fmt.Errorf("Import id must be of the form %s, got: %s", strings.Join(attributes, "/"), d.Id())
+		}
+		for i, attribute := range attributes {
+			d.Set(attribute, parts[i])
+		}
+		d.SetId(parts[len(parts)-1])
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+func updateAnnotations(client *APIClient, d *schema.ResourceData, action string, payload interface{}) error {
+	path := fmt.Sprintf(
+		"%s/%s/annotationSets/%s/annotations/update",
+		entity_paths[d.Get("entity_type").(string)],
+		d.Get("entity"),
+		d.Id(),
+	)
+	params := map[string]string{
+		"study":  d.Get("study").(string),
+		"action": action,
+	}
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func getVariableSet(client *APIClient, id string) (*VariableSet, error) {
+	path := fmt.Sprintf("variableset/%s/info", id)
+	req, err := buildRequest(client, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, fmt.Errorf("Failed to find VariableSet %s, got %d results", id, len(resp.Results))
+	}
+	var variableSet VariableSet
+	err = mapstructure.Decode(resp.Results[0], &variableSet)
+	if err != nil {
+		return nil, err
+	}
+	return &variableSet, nil
+}
+
+func expandAnnotations(values map[string]interface{}, json_values string, variableSet *VariableSet) (map[string]interface{}, error) {
+	// Build the annotations payload from either the map or json attribute.
+	// When a variable set is given the values are converted to the variable
+	// types and checked against the definition.
+	annotations := make(map[string]interface{})
+	if json_values != "" {
+		if err := json.Unmarshal([]byte(json_values), &annotations); err != nil {
+			return nil, fmt.Errorf("Unable to convert annotations_json to a json object: %s", err)
+		}
+	} else {
+		for k, v := range values {
+			annotations[k] = v
+		}
+	}
+	if variableSet == nil {
+		return annotations, nil
+	}
+
+	variables := make(map[string]map[string]interface{})
+	for _, v := range variableSet.Variables {
+		variable, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := variable["name"].(string); ok {
+			variables[name] = variable
+		} else if id, ok := variable["id"].(string); ok {
+			variables[id] = variable
+		}
+	}
+
+	var problems []string
+	for name, variable := range variables {
+		if required, _ := variable["required"].(bool); required {
+			if _, ok := annotations[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s is required", name))
+			}
+		}
+	}
+	for name, value := range annotations {
+		variable, ok := variables[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not defined in variable set %s", name, variableSet.Name))
+			continue
+		}
+		converted, err := convertAnnotation(variable, value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+		annotations[name] = converted
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("Invalid annotations for variable set %s:\n  %s", variableSet.Name, strings.Join(problems, "\n  "))
+	}
+	return annotations, nil
+}
+
+func convertAnnotation(variable map[string]interface{}, value interface{}) (interface{}, error) {
+	// Strings from the annotations map are converted to the variable type,
+	// json values are only checked
+	multiValue, _ := variable["multiValue"].(bool)
+	if multiValue {
+		var values []interface{}
+		switch v := value.(type) {
+		case string:
+			for _, s := range strings.Split(v, ",") {
+				values = append(values, strings.TrimSpace(s))
+			}
+		case []interface{}:
+			values = v
+		default:
+			values = []interface{}{v}
+		}
+		for i, v := range values {
+			converted, err := convertAnnotationValue(variable, v)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = converted
+		}
+		return values, nil
+	}
+	return convertAnnotationValue(variable, value)
+}
+
+func convertAnnotationValue(variable map[string]interface{}, value interface{}) (interface{}, error) {
+	variableType, _ := variable["type"].(string)
+	s, isString := value.(string)
+	switch variableType {
+	case "BOOLEAN":
+		if isString {
+			return strconv.ParseBool(s)
+		}
+		if _, ok := value.(bool); !ok {
+			return nil, fmt.Errorf("expected a BOOLEAN, got %v", value)
+		}
+	case "INTEGER":
+		if isString {
+			return strconv.Atoi(s)
+		}
+		if f, ok := value.(float64); !ok || f != float64(int(f)) {
+			return nil, fmt.Errorf("expected an INTEGER, got %v", value)
+		}
+	case "DOUBLE":
+		if isString {
+			return strconv.ParseFloat(s, 64)
+		}
+		if _, ok := value.(float64); !ok {
+			return nil, fmt.Errorf("expected a DOUBLE, got %v", value)
+		}
+	case "CATEGORICAL":
+		allowed, _ := variable["allowedValues"].([]interface{})
+		for _, a := range allowed {
+			if fmt.Sprint(a) == fmt.Sprint(value) {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("%v is not one of the allowed values %v", value, allowed)
+	case "TEXT", "STRING":
+		if !isString {
+			return nil, fmt.Errorf("expected %s, got %v", variableType, value)
+		}
+	case "OBJECT", "MAP_BOOLEAN", "MAP_INTEGER", "MAP_DOUBLE", "MAP_STRING":
+		if isString {
+			return nil, fmt.Errorf("%s variables must be set with annotations_json", variableType)
+		}
+	}
+	return value, nil
+}
+
+func flattenAnnotations(annotations map[string]interface{}) map[string]interface{} {
+	// Render annotation values in the string form used by the annotations map
+	result := make(map[string]interface{}, len(annotations))
+	for k, v := range annotations {
+		switch value := v.(type) {
+		case []interface{}:
+			values := make([]string, len(value))
+			for i, item := range value {
+				values[i] = flattenAnnotationValue(item)
+			}
+			result[k] = strings.Join(values, ",")
+		default:
+			result[k] = flattenAnnotationValue(value)
+		}
+	}
+	return result
+}
+
+func flattenAnnotationValue(v interface{}) string {
+	switch value := v.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,153 @@
+package opencga
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Variable set with one variable of each kind checked by expandAnnotations
+func newTestVariableSet() *VariableSet {
+	return &VariableSet{
+		Id:   1,
+		Name: "consent",
+		Variables: []interface{}{
+			map[string]interface{}{"id": "consent_type", "type": "CATEGORICAL", "required": true, "allowedValues": []interface{}{"research", "clinical"}},
+			map[string]interface{}{"id": "signed", "type": "BOOLEAN"},
+			map[string]interface{}{"id": "age", "type": "INTEGER"},
+			map[string]interface{}{"id": "score", "type": "DOUBLE"},
+			map[string]interface{}{"id": "notes", "type": "STRING"},
+			map[string]interface{}{"id": "tissues", "type": "STRING", "multiValue": true},
+			map[string]interface{}{"id": "metadata", "type": "OBJECT"},
+		},
+	}
+}
+
+func TestExpandAnnotationsWithoutVariableSet(t *testing.T) {
+	annotations, err := expandAnnotations(map[string]interface{}{"anything": "1"}, "", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(annotations, map[string]interface{}{"anything": "1"}) {
+		t.Fatalf("expected the values unchanged, got %v", annotations)
+	}
+}
+
+func TestExpandAnnotationsConvertsMapValues(t *testing.T) {
+	values := map[string]interface{}{
+		"consent_type": "research",
+		"signed":       "true",
+		"age":          "42",
+		"score":        "0.5",
+		"notes":        "none",
+		"tissues":      "blood, saliva",
+	}
+	annotations, err := expandAnnotations(values, "", newTestVariableSet())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := map[string]interface{}{
+		"consent_type": "research",
+		"signed":       true,
+		"age":          42,
+		"score":        0.5,
+		"notes":        "none",
+		"tissues":      []interface{}{"blood", "saliva"},
+	}
+	if !reflect.DeepEqual(annotations, expected) {
+		t.Fatalf("expected %v, got %v", expected, annotations)
+	}
+}
+
+func TestExpandAnnotationsChecksJsonValues(t *testing.T) {
+	json := `{"consent_type": "clinical", "signed": false, "age": 42, "tissues": ["blood"], "metadata": {"batch": 1}}`
+	annotations, err := expandAnnotations(nil, json, newTestVariableSet())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	expected := map[string]interface{}{
+		"consent_type": "clinical",
+		"signed":       false,
+		"age":          float64(42),
+		"tissues":      []interface{}{"blood"},
+		"metadata":     map[string]interface{}{"batch": float64(1)},
+	}
+	if !reflect.DeepEqual(annotations, expected) {
+		t.Fatalf("expected %v, got %v", expected, annotations)
+	}
+}
+
+func TestExpandAnnotationsRejectsInvalidJson(t *testing.T) {
+	_, err := expandAnnotations(nil, `{"consent_type": `, newTestVariableSet())
+	if err == nil || !strings.Contains(err.Error(), "annotations_json") {
+		t.Fatalf("expected a json error, got %v", err)
+	}
+}
+
+func TestExpandAnnotationsRejectsInvalidValues(t *testing.T) {
+	values := map[string]interface{}{
+		"consent_type": "unknown",
+		"signed":       "maybe",
+		"age":          "forty",
+		"score":        "high",
+		"metadata":     "batch=1",
+		"undefined":    "x",
+	}
+	_, err := expandAnnotations(values, "", newTestVariableSet())
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	for _, name := range []string{"consent_type", "signed", "age", "score", "metadata", "undefined"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected %s to be reported, got %s", name, err)
+		}
+	}
+}
+
+func TestExpandAnnotationsRejectsInvalidJsonTypes(t *testing.T) {
+	json := `{"consent_type": "research", "signed": "yes", "age": 4.2, "score": "high", "notes": 1}`
+	_, err := expandAnnotations(nil, json, newTestVariableSet())
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	for _, name := range []string{"signed", "age", "score", "notes"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Fatalf("expected %s to be reported, got %s", name, err)
+		}
+	}
+}
+
+func TestExpandAnnotationsRequiresVariables(t *testing.T) {
+	_, err := expandAnnotations(map[string]interface{}{"signed": "true"}, "", newTestVariableSet())
+	if err == nil || !strings.Contains(err.Error(), "consent_type is required") {
+		t.Fatalf("expected a required variable error, got %v", err)
+	}
+}
+
+func TestConvertAnnotationMultiValue(t *testing.T) {
+	variable := map[string]interface{}{"id": "ages", "type": "INTEGER", "multiValue": true}
+	converted, err := convertAnnotation(variable, "1,2, 3")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(converted, []interface{}{1, 2, 3}) {
+		t.Fatalf("expected [1 2 3], got %v", converted)
+	}
+	if _, err := convertAnnotation(variable, "1,two"); err == nil {
+		t.Fatalf("expected an error for a value that is not an INTEGER")
+	}
+}
+
+func TestConvertAnnotationCategoricalMultiValue(t *testing.T) {
+	variable := map[string]interface{}{"id": "tissue", "type": "CATEGORICAL", "multiValue": true, "allowedValues": []interface{}{"blood", "saliva"}}
+	converted, err := convertAnnotation(variable, "blood,saliva")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !reflect.DeepEqual(converted, []interface{}{"blood", "saliva"}) {
+		t.Fatalf("expected [blood saliva], got %v", converted)
+	}
+	if _, err := convertAnnotation(variable, "blood,urine"); err == nil {
+		t.Fatalf("expected an error for a value that is not allowed")
+	}
+}
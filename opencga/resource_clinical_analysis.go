@@ -0,0 +1,315 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+var clinical_analysis_types = []string{"SINGLE", "FAMILY", "CANCER"}
+
+func resourceClinicalAnalysis() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClinicalAnalysisCreate,
+		ReadContext:   resourceClinicalAnalysisRead,
+		UpdateContext: resourceClinicalAnalysisUpdate,
+		DeleteContext: resourceClinicalAnalysisDelete,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study this clinical analysis belongs to.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Clinical analysis id, must be unique within the study.",
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(clinical_analysis_types, false),
+				Description:  "Type of clinical analysis, can be one of: SINGLE, FAMILY, CANCER.",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Clinical analysis description",
+			},
+			"proband": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the individual that is the proband of this case.",
+			},
+			"family": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The id of the family of the proband, required for FAMILY analyses.",
+			},
+			"disorder": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The id of the disorder being investigated, must be one of the proband's disorders.",
+			},
+			"panels": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the disease panels used in this case.",
+			},
+			"analysts": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "User ids of the analysts assigned to this case.",
+			},
+			"priority": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Priority id as configured in the study clinical configuration, e.g. URGENT, HIGH, MEDIUM, LOW.",
+			},
+			"flags": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Flag ids as configured in the study clinical configuration.",
+			},
+			"due_date": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Due date in the OpenCGA date format YYYYMMDDHHMMSS.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Status id as configured in the study clinical configuration.",
+			},
+			"interpretations": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the interpretations of this case, the primary interpretation is listed first.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("study", "name"),
+		},
+	}
+}
+
+func resourceClinicalAnalysisCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	payload := map[string]interface{}{
+		"id":      d.Get("name").(string),
+		"type":    d.Get("type").(string),
+		"proband": map[string]string{"id": d.Get("proband").(string)},
+	}
+	if v, ok := d.GetOk("family"); ok {
+		payload["family"] = map[string]string{"id": v.(string)}
+	}
+	for k, v := range clinicalAnalysisPayload(d) {
+		payload[k] = v
+	}
+
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	path := "analysis/clinical/create"
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var clinicalAnalysis ClinicalAnalysis
+	err = mapstructure.Decode(resp.Results[0], &clinicalAnalysis)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(clinicalAnalysis.Id)
+	resourceClinicalAnalysisRead(ctx, d, m)
+	return diags
+}
+
+func resourceClinicalAnalysisRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("analysis/clinical/%s/info", d.Id())
+	// Clinical analysis ids are only unique within a study
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find clinical analysis, got %d results", len(resp.Results))
+	}
+	var clinicalAnalysis ClinicalAnalysis
+	err = mapstructure.Decode(resp.Results[0], &clinicalAnalysis)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The primary interpretation is listed ahead of any secondary ones
+	interpretations := make([]string, 0)
+	if clinicalAnalysis.Interpretation.Id != "" {
+		interpretations = append(interpretations, clinicalAnalysis.Interpretation.Id)
+	}
+	interpretations = append(interpretations, flattenEntityRefs(clinicalAnalysis.SecondaryInterpretations)...)
+
+	d.Set("name", clinicalAnalysis.Id)
+	d.Set("type", clinicalAnalysis.Type)
+	d.Set("description", clinicalAnalysis.Description)
+	d.Set("proband", clinicalAnalysis.Proband.Id)
+	d.Set("family", clinicalAnalysis.Family.Id)
+	d.Set("disorder", clinicalAnalysis.Disorder.Id)
+	d.Set("panels", flattenEntityRefs(clinicalAnalysis.Panels))
+	d.Set("analysts", flattenEntityRefs(clinicalAnalysis.Analysts))
+	d.Set("priority", clinicalAnalysis.Priority.Id)
+	d.Set("flags", flattenEntityRefs(clinicalAnalysis.Flags))
+	d.Set("due_date", clinicalAnalysis.DueDate)
+	d.Set("status", clinicalAnalysis.Status.Id)
+	d.Set("interpretations", interpretations)
+	return diags
+}
+
+func resourceClinicalAnalysisUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	// Only send the attributes that have changed, the list attributes are
+	// replaced wholesale rather than merged with the existing values
+	payload := make(map[string]interface{})
+	for k, v := range clinicalAnalysisPayload(d) {
+		if d.HasChange(clinicalAnalysisPayloadKeys[k]) {
+			payload[k] = v
+		}
+	}
+	if len(payload) == 0 {
+		return resourceClinicalAnalysisRead(ctx, d, m)
+	}
+
+	params := map[string]string{
+		"study":          d.Get("study").(string),
+		"panelsAction":   "SET",
+		"analystsAction": "SET",
+		"flagsAction":    "SET",
+	}
+	path := fmt.Sprintf("analysis/clinical/%s/update", d.Id())
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClinicalAnalysisRead(ctx, d, m)
+}
+
+func resourceClinicalAnalysisDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("analysis/clinical/%s/delete", d.Id())
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+// Maps the updatable clinical analysis payload keys to their schema attribute
+var clinicalAnalysisPayloadKeys = map[string]string{
+	"description": "description",
+	"disorder":    "disorder",
+	"panels":      "panels",
+	"analysts":    "analysts",
+	"priority":    "priority",
+	"flags":       "flags",
+	"dueDate":     "due_date",
+	"status":      "status",
+}
+
+func clinicalAnalysisPayload(d *schema.ResourceData) map[string]interface{} {
+	// Build the payload entries shared by create and update requests
+	payload := map[string]interface{}{
+		"description": d.Get("description").(string),
+		"panels":      expandEntityRefs(d.Get("panels").(*schema.Set).List()),
+		"analysts":    expandEntityRefs(d.Get("analysts").(*schema.Set).List()),
+		"flags":       expandEntityRefs(d.Get("flags").(*schema.Set).List()),
+	}
+	if v, ok := d.GetOk("disorder"); ok {
+		payload["disorder"] = map[string]string{"id": v.(string)}
+	} else if d.HasChange("disorder") {
+		// A removed disorder is only cleared by sending an empty one
+		payload["disorder"] = map[string]string{}
+	}
+	if v, ok := d.GetOk("priority"); ok {
+		payload["priority"] = map[string]string{"id": v.(string)}
+	}
+	if v, ok := d.GetOk("due_date"); ok {
+		payload["dueDate"] = v.(string)
+	}
+	if v, ok := d.GetOk("status"); ok {
+		payload["status"] = map[string]string{"id": v.(string)}
+	}
+	return payload
+}
+
+func expandEntityRefs(ids []interface{}) []map[string]string {
+	// Convert a list of ids into the {"id": ...} objects expected by OpenCGA
+	refs := make([]map[string]string, len(ids))
+	for i, id := range ids {
+		refs[i] = map[string]string{"id": id.(string)}
+	}
+	return refs
+}
+
+func flattenEntityRefs(refs []EntityRef) []string {
+	ids := make([]string, len(refs))
+	for i, r := range refs {
+		ids[i] = r.Id
+	}
+	return ids
+}
@@ -0,0 +1,362 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Permissions that can be granted on each entity type
+var entity_permissions = map[string][]string{
+	"sample":     {"VIEW", "WRITE", "DELETE", "VIEW_ANNOTATIONS", "WRITE_ANNOTATIONS", "DELETE_ANNOTATIONS", "VIEW_VARIANTS"},
+	"file":       {"VIEW", "VIEW_HEADER", "VIEW_CONTENT", "WRITE", "DELETE", "DOWNLOAD", "UPLOAD", "VIEW_ANNOTATIONS", "WRITE_ANNOTATIONS", "DELETE_ANNOTATIONS"},
+	"individual": {"VIEW", "WRITE", "DELETE", "VIEW_ANNOTATIONS", "WRITE_ANNOTATIONS", "DELETE_ANNOTATIONS"},
+	"family":     {"VIEW", "WRITE", "DELETE", "VIEW_ANNOTATIONS", "WRITE_ANNOTATIONS", "DELETE_ANNOTATIONS"},
+	"cohort":     {"VIEW", "WRITE", "DELETE", "VIEW_ANNOTATIONS", "WRITE_ANNOTATIONS", "DELETE_ANNOTATIONS"},
+	"panel":      {"VIEW", "WRITE", "DELETE"},
+	"job":        {"VIEW", "WRITE", "DELETE"},
+}
+
+// Number of entities whose ACLs are read in a single request
+const entity_acl_batch_size = 100
+
+var acl_entity_types = []string{"sample", "file", "individual", "family", "cohort", "panel", "job"}
+
+func resourceEntityACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEntityACLCreate,
+		ReadContext:   resourceEntityACLRead,
+		UpdateContext: resourceEntityACLUpdate,
+		DeleteContext: resourceEntityACLDelete,
+		CustomizeDiff: resourceEntityACLCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study the entities belong to.",
+			},
+			"entity_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(acl_entity_types, false),
+				Description:  "Type of entity, can be one of: sample, file, individual, family, cohort, panel, job.",
+			},
+			"entity_ids": &schema.Schema{
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"entity_ids", "query"},
+				Description:  "Ids of the entities the ACL is applied to.",
+			},
+			"query": &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ExactlyOneOf: []string{"entity_ids", "query"},
+				Description:  "Entity search parameters selecting the entities the ACL is applied to. The query is resolved on every plan, newly matching entities are shown as a change.",
+			},
+			"member": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "This can be a user name or group id.",
+			},
+			"permissions": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "OpenCGA permissions allowed for the entity type, e.g. VIEW, VIEW_ANNOTATIONS.",
+			},
+			"propagate": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Also apply the permissions to related entities, samples of an individual or individuals of a sample.",
+			},
+			"resolved_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the entities the ACL is currently applied to.",
+			},
+		},
+	}
+}
+
+func resourceEntityACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	ids, err := resolveEntityIds(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = updateEntityACL(client, d, "SET", ids, setToStrings(d.Get("permissions").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("member").(string))
+	resourceEntityACLRead(ctx, d, m)
+	return diags
+}
+
+func resourceEntityACLRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	ids := setToStrings(d.Get("resolved_ids").(*schema.Set))
+	desired := strings.Join(setToStrings(d.Get("permissions").(*schema.Set)), ",")
+	permissions := d.Get("permissions").(*schema.Set).List()
+	member := d.Get("member").(string)
+	drifted := false
+	for start := 0; start < len(ids) && !drifted; start += entity_acl_batch_size {
+		end := start + entity_acl_batch_size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		acls, err := getEntityBatchACLs(client, d.Get("study").(string), d.Get("entity_type").(string), ids[start:end], member)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Report the permissions of the first entity that differs from
+		// the configuration so the drift shows up in the plan
+		for _, entityACLs := range acls {
+			actual := make([]string, 0)
+			for _, acl := range entityACLs {
+				if acl.Member == member {
+					actual = acl.Permissions
+				}
+			}
+			sort.Strings(actual)
+			if strings.Join(actual, ",") != desired {
+				permissions = stringsToInterfaces(actual)
+				drifted = true
+				break
+			}
+		}
+	}
+
+	d.Set("permissions", permissions)
+	return diags
+}
+
+func resourceEntityACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	// Entities no longer selected have the permissions removed, the
+	// current selection is SET so both new entities and changed
+	// permissions are applied
+	oldIds, _ := d.GetChange("resolved_ids")
+	oldPermissions, _ := d.GetChange("permissions")
+	ids, err := resolveEntityIds(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	removed := setToStrings(oldIds.(*schema.Set).Difference(schema.NewSet(schema.HashString, stringsToInterfaces(ids))))
+	if len(removed) > 0 {
+		err = updateEntityACL(client, d, "REMOVE", removed, setToStrings(oldPermissions.(*schema.Set)))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	err = updateEntityACL(client, d, "SET", ids, setToStrings(d.Get("permissions").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceEntityACLRead(ctx, d, m)
+}
+
+func resourceEntityACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	ids := setToStrings(d.Get("resolved_ids").(*schema.Set))
+	err := updateEntityACL(client, d, "REMOVE", ids, setToStrings(d.Get("permissions").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceEntityACLCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Permissions depend on the entity type so can't be checked by the schema
+	entityType := d.Get("entity_type").(string)
+	if d.NewValueKnown("permissions") {
+		var invalid []string
+		for _, p := range setToStrings(d.Get("permissions").(*schema.Set)) {
+			if !stringInSlice(p, entity_permissions[entityType]) {
+				invalid = append(invalid, p)
+			}
+		}
+		if len(invalid) > 0 {
+			return fmt.Errorf("permissions %v are not valid for %s, must be from %v", invalid, entityType, entity_permissions[entityType])
+		}
+	}
+	if d.Get("propagate").(bool) && entityType != "sample" && entityType != "individual" {
+		return fmt.Errorf("propagate can only be used with sample or individual ACLs")
+	}
+
+	// Resolve the selection during plan so new or removed entities are shown
+	if !d.NewValueKnown("entity_ids") || !d.NewValueKnown("query") || !d.NewValueKnown("study") {
+		return d.SetNewComputed("resolved_ids")
+	}
+	var ids []string
+	if v, ok := d.GetOk("entity_ids"); ok {
+		ids = setToStrings(v.(*schema.Set))
+	} else {
+		var err error
+		ids, err = searchEntityIds(m.(*APIClient), d.Get("study").(string), entityType, d.Get("query").(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+	}
+	current := setToStrings(d.Get("resolved_ids").(*schema.Set))
+	if d.Id() == "" || strings.Join(current, ",") != strings.Join(ids, ",") {
+		return d.SetNew("resolved_ids", ids)
+	}
+	return nil
+}
+
+func resolveEntityIds(client *APIClient, d *schema.ResourceData) ([]string, error) {
+	// Use the selection resolved during plan, it is only unknown when the
+	// entity ids or query depend on other resources
+	ids := setToStrings(d.Get("resolved_ids").(*schema.Set))
+	if len(ids) > 0 {
+		return ids, nil
+	}
+	var err error
+	if v, ok := d.GetOk("entity_ids"); ok {
+		ids = setToStrings(v.(*schema.Set))
+	} else {
+		ids, err = searchEntityIds(client, d.Get("study").(string), d.Get("entity_type").(string), d.Get("query").(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+	}
+	d.Set("resolved_ids", ids)
+	return ids, nil
+}
+
+func updateEntityACL(client *APIClient, d *schema.ResourceData, action string, ids []string, permissions []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	entityType := d.Get("entity_type").(string)
+	payload := map[string]interface{}{
+		"action":      action,
+		"permissions": strings.Join(permissions, ","),
+		entityType:    strings.Join(ids, ","),
+	}
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	if d.Get("propagate").(bool) {
+		params["propagate"] = "true"
+	}
+	path := fmt.Sprintf("%s/acl/%s/update", entity_paths[entityType], d.Get("member"))
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func getEntityBatchACLs(client *APIClient, study string, entityType string, ids []string, member string) ([][]StudyACL, error) {
+	// The ACLs of several entities are returned as one response per entity,
+	// in the order of the requested ids
+	path := fmt.Sprintf("%s/%s/acl", entity_paths[entityType], strings.Join(ids, ","))
+	params := map[string]string{
+		"study":  study,
+		"member": member,
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	responses, err := client.CallAll(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(responses) != len(ids) {
+		return nil, fmt.Errorf("Failed to read %s ACLs, got %d responses for %d entities", entityType, len(responses), len(ids))
+	}
+	acls := make([][]StudyACL, len(responses))
+	for i, resp := range responses {
+		err = mapstructure.Decode(resp.Results, &acls[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acls, nil
+}
+
+func searchEntityIds(client *APIClient, study string, entityType string, query map[string]interface{}) ([]string, error) {
+	path := fmt.Sprintf("%s/search", entity_paths[entityType])
+	params := map[string]string{
+		"study":   study,
+		"include": "id",
+	}
+	for k, v := range query {
+		params[k] = fmt.Sprint(v)
+	}
+	results, err := client.Search(path, params, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(results))
+	for i, r := range results {
+		entity, _ := r.(map[string]interface{})
+		ids[i] = flattenAnnotationValue(entity["id"])
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func setToStrings(s *schema.Set) []string {
+	// Sorted so that the result can be compared and sent in a stable order
+	result := make([]string, s.Len())
+	for i, v := range s.List() {
+		result[i] = v.(string)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func stringsToInterfaces(s []string) []interface{} {
+	result := make([]interface{}, len(s))
+	for i, v := range s {
+		result[i] = v
+	}
+	return result
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
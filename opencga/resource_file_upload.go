@@ -0,0 +1,320 @@
+package opencga
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+func resourceFileUpload() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFileUploadCreate,
+		ReadContext:   resourceFileUploadRead,
+		UpdateContext: resourceFileUploadUpdate,
+		DeleteContext: resourceFileUploadDelete,
+		CustomizeDiff: resourceFileUploadCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The `id` of the study this file is uploaded to.",
+			},
+			"source": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Local path of the file to upload. The content is hashed on every plan and changes cause the file to be uploaded again.",
+			},
+			"path": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePathFunc,
+				Description:      "Catalog directory path to upload into, e.g. sample/. Can be the `path` of an `opencga_folder`.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "File name in the catalog, defaults to the name of the source file.",
+			},
+			"format": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "OpenCGA file format, e.g. PED, BED, PLAIN. Detected by OpenCGA if not set.",
+			},
+			"bioformat": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "OpenCGA bioformat, e.g. PEDIGREE, NONE. Detected by OpenCGA if not set.",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "File description",
+			},
+			"parents": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Create any missing parent directories of the path.",
+			},
+			"chunk_threshold": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50 * 1024 * 1024,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Files larger than this many bytes are uploaded in chunks. Defaults to 50MiB.",
+			},
+			"chunk_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10 * 1024 * 1024,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Size in bytes of each chunk of a chunked upload. Defaults to 10MiB.",
+			},
+			"content_sha256": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA256 hash of the uploaded content.",
+			},
+			"size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the file in bytes as recorded by OpenCGA.",
+			},
+			"uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceFileUploadCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	// Uploads create parent directories, so share the file linking mutex
+	client.Mutex.Lock()
+	defer client.Mutex.Unlock()
+
+	source := d.Get("source").(string)
+	content, err := os.Open(source)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer content.Close()
+	info, err := content.Stat()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Hash the content as it is uploaded so the state matches what was
+	// sent even if the file changes in the meantime
+	hash := sha256.New()
+	reader := io.TeeReader(content, hash)
+
+	name := filepath.Base(source)
+	if v, ok := d.GetOk("name"); ok {
+		name = v.(string)
+	}
+	fields := map[string]string{
+		"filename":         name,
+		"relativeFilePath": d.Get("path").(string) + name,
+		"description":      d.Get("description").(string),
+		"parents":          strconv.FormatBool(d.Get("parents").(bool)),
+	}
+	if v, ok := d.GetOk("format"); ok {
+		fields["fileFormat"] = v.(string)
+	}
+	if v, ok := d.GetOk("bioformat"); ok {
+		fields["bioformat"] = v.(string)
+	}
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+
+	var resp *Response
+	if info.Size() > int64(d.Get("chunk_threshold").(int)) {
+		resp, err = uploadChunks(client, fields, name, reader, info.Size(), d.Get("chunk_size").(int), params)
+	} else {
+		resp, err = uploadFile(client, fields, name, reader, params)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var file File
+	err = mapstructure.Decode(resp.Results[0], &file)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(file.Id))
+	d.Set("content_sha256", hex.EncodeToString(hash.Sum(nil)))
+	resourceFileUploadRead(ctx, d, m)
+	return diags
+}
+
+func resourceFileUploadRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("files/%s/info", d.Id())
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find File, got %d results", len(resp.Results))
+	}
+	var file File
+	err = mapstructure.Decode(resp.Results[0], &file)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", file.Name)
+	d.Set("format", file.Format)
+	d.Set("bioformat", file.Bioformat)
+	d.Set("size", file.Size)
+	d.Set("uri", file.Uri)
+	// Remove the file from the path, OpenCGA adds this in the response
+	d.Set("path", filepath.Dir(file.Path)+"/")
+	return diags
+}
+
+func resourceFileUploadUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Content changes replace the resource, the remaining attributes
+	// only affect how the next upload is performed
+	return resourceFileUploadRead(ctx, d, m)
+}
+
+func resourceFileUploadDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	// Uploaded files live in the OpenCGA workspace, so nothing outside the
+	// catalog is lost by skipping the trash
+	path := fmt.Sprintf("files/%s/delete", d.Id())
+	params := map[string]string{
+		"study":     d.Get("study").(string),
+		"skipTrash": "true",
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceFileUploadCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Hash the local file so that content changes are shown in the plan
+	// and trigger a new upload
+	if !d.NewValueKnown("source") {
+		return nil
+	}
+	hash, err := fileSha256(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+	if d.Get("content_sha256").(string) == hash {
+		return nil
+	}
+	if err := d.SetNew("content_sha256", hash); err != nil {
+		return err
+	}
+	if d.Id() != "" {
+		return d.ForceNew("content_sha256")
+	}
+	return nil
+}
+
+func uploadFile(client *APIClient, fields map[string]string, name string, content io.Reader, params map[string]string) (*Response, error) {
+	req, err := buildUploadRequest(client, "files/upload", fields, name, content, params)
+	if err != nil {
+		return nil, err
+	}
+	return client.Call(req)
+}
+
+func uploadChunks(client *APIClient, fields map[string]string, name string, content io.Reader, size int64, chunkSize int, params map[string]string) (*Response, error) {
+	// Each chunk is sent as a separate upload request, OpenCGA joins the
+	// chunks and creates the catalog entry once the last one is received
+	total := int((size + int64(chunkSize) - 1) / int64(chunkSize))
+	buf := make([]byte, chunkSize)
+	var resp *Response
+	for i := 0; i < total; i++ {
+		n, err := io.ReadFull(content, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return nil, err
+		}
+
+		chunk_fields := map[string]string{
+			"chunk_id":      strconv.Itoa(i),
+			"chunk_size":    strconv.Itoa(n),
+			"chunk_total":   strconv.Itoa(total),
+			"last_chunk":    strconv.FormatBool(i == total-1),
+			"resume_upload": "false",
+		}
+		for key, val := range fields {
+			chunk_fields[key] = val
+		}
+		resp, err = uploadFile(client, chunk_fields, name, bytes.NewReader(buf[:n]), params)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to upload chunk %d of %d: %s", i+1, total, err)
+		}
+	}
+	return resp, nil
+}
+
+func fileSha256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
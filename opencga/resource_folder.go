@@ -0,0 +1,245 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func resourceFolder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceFolderCreate,
+		ReadContext:   resourceFolderRead,
+		UpdateContext: resourceFolderUpdate,
+		DeleteContext: resourceFolderDelete,
+		CustomizeDiff: resourceFolderCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The `id` of the study this folder is associated with.",
+			},
+			"path": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePathFunc,
+				Description:      "Catalog directory path ending in /, e.g. genomes/sample/. This can be used as the `path` of an `opencga_file`.",
+			},
+			"uri": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Optional filesystem directory (URI) to link the folder to, e.g. /genomes/sample. The last directory name of `path` must match the uri, this is checked during plan. If not set the folder only exists in the catalog.",
+			},
+			"parents": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Create any missing parent directories of the path.",
+			},
+			"recursive": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow the folder to be deleted while it still contains files or folders.",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFolderImport,
+		},
+	}
+}
+
+func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	// Share the file linking mutex, creating parents has the same race
+	// condition as linking files that share paths
+	client.Mutex.Lock()
+	defer client.Mutex.Unlock()
+
+	params := map[string]string{
+		"study":   d.Get("study").(string),
+		"parents": strconv.FormatBool(d.Get("parents").(bool)),
+	}
+
+	var path string
+	var payload map[string]interface{}
+	if v, ok := d.GetOk("uri"); ok {
+		path = "files/link"
+		payload = map[string]interface{}{
+			"description":  "",
+			"relatedFiles": []string{},
+			"uri":          v.(string),
+			"path":         parentPath(d.Get("path").(string)),
+		}
+		params["type"] = "DIRECTORY"
+		params["createFolder"] = "false"
+	} else {
+		path = "files/create"
+		payload = map[string]interface{}{
+			"path":      d.Get("path").(string),
+			"directory": true,
+		}
+	}
+
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var folder File
+	err = mapstructure.Decode(resp.Results[0], &folder)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(folder.Id))
+	resourceFolderRead(ctx, d, m)
+	return diags
+}
+
+func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("files/%s/info", d.Id())
+	req, err := buildRequest(client, path, nil, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find folder, got %d results", len(resp.Results))
+	}
+	var folder File
+	err = mapstructure.Decode(resp.Results[0], &folder)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if folder.Type != "DIRECTORY" {
+		return diag.Errorf("Catalog entry %s is a %s, not a DIRECTORY", d.Id(), folder.Type)
+	}
+
+	d.Set("name", folder.Name)
+	d.Set("path", folder.Path)
+	// Folders that are not linked still have a uri inside the OpenCGA
+	// workspace, only external uris are managed by this resource
+	if folder.External {
+		d.Set("uri", strings.TrimSuffix(strings.Replace(folder.Uri, "file://", "", 1), "/"))
+	}
+	return diags
+}
+
+func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only the recursive flag can change and that is not stored in OpenCGA
+	return resourceFolderRead(ctx, d, m)
+}
+
+func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	if !d.Get("recursive").(bool) {
+		path := fmt.Sprintf("files/%s/list", d.Id())
+		params := map[string]string{
+			"study":   d.Get("study").(string),
+			"include": "id",
+			"limit":   "1",
+		}
+		req, err := buildRequest(client, path, nil, params)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		resp, err := client.Call(req)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(resp.Results) > 0 {
+			return diag.Errorf(
+				"Folder %s is not empty, set recursive = true to delete it with its contents",
+				d.Get("path"),
+			)
+		}
+	}
+
+	// Linked folders are unlinked so the data on the filesystem is untouched
+	action := "delete"
+	if _, ok := d.GetOk("uri"); ok {
+		action = "unlink"
+	}
+	path := fmt.Sprintf("files/%s/%s", d.Id(), action)
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceFolderCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Linked folders are named after the uri, so a path with a different
+	// name would never match the folder that is created
+	if !d.NewValueKnown("uri") || !d.NewValueKnown("path") || d.Get("uri").(string) == "" {
+		return nil
+	}
+	path := d.Get("path").(string)
+	uri := d.Get("uri").(string)
+	if lastPathElement(path) != lastPathElement(uri) {
+		return fmt.Errorf("The last directory of path %s must match the last directory of uri %s", path, uri)
+	}
+	return nil
+}
+
+func resourceFolderImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	// Folders are imported using study/id, the create options are not
+	// stored in OpenCGA so they take their defaults
+	d.Set("parents", true)
+	d.Set("recursive", false)
+	return importStateIdFunc("study", "id")(ctx, d, m)
+}
+
+func lastPathElement(path string) string {
+	path = strings.TrimSuffix(path, "/")
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+func parentPath(path string) string {
+	// Linking uses the parent directory as the path, the folder name is
+	// taken from the uri
+	return path[:strings.LastIndex(strings.TrimSuffix(path, "/"), "/")+1]
+}
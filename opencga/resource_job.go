@@ -0,0 +1,319 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Run endpoint of each tool that can be submitted without a run_path
+var job_tool_paths = map[string]string{
+	"alignment-qc":         "analysis/alignment/qc/run",
+	"cohort-variant-stats": "analysis/variant/cohort/stats/run",
+	"family-qc":            "analysis/variant/family/qc/run",
+	"gwas":                 "analysis/variant/gwas/run",
+	"individual-qc":        "analysis/variant/individual/qc/run",
+	"inferred-sex":         "analysis/variant/inferredSex/run",
+	"knockout":             "analysis/variant/knockout/run",
+	"mendelian-error":      "analysis/variant/mendelianError/run",
+	"mutational-signature": "analysis/variant/mutationalSignature/run",
+	"relatedness":          "analysis/variant/relatedness/run",
+	"sample-qc":            "analysis/variant/sample/qc/run",
+	"sample-variant-stats": "analysis/variant/sample/stats/run",
+	"variant-export":       "analysis/variant/export/run",
+	"variant-stats":        "analysis/variant/stats/run",
+}
+
+var job_pending_statuses = []string{"PENDING", "QUEUED", "RUNNING"}
+
+// Number of lines of stderr to include in the error of a failed job
+const job_stderr_lines = 50
+
+func resourceJob() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Submits a tool or analysis as an OpenCGA job and waits for it to finish.",
+		CreateContext: resourceJobCreate,
+		ReadContext:   resourceJobRead,
+		UpdateContext: resourceJobUpdate,
+		DeleteContext: resourceJobDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study the job runs in.",
+			},
+			"tool": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "Id of the tool or analysis to run. The run endpoint is known for: alignment-qc, cohort-variant-stats, " +
+					"family-qc, gwas, individual-qc, inferred-sex, knockout, mendelian-error, mutational-signature, relatedness, " +
+					"sample-qc, sample-variant-stats, variant-export, variant-stats",
+			},
+			"run_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "Run endpoint of the tool relative to the webservices url, e.g. `analysis/variant/gwas/run`. " +
+					"Only needed for tools without a known endpoint.",
+			},
+			"params": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Parameters passed to the tool in the body of the run request",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Job description",
+			},
+			"poll_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Seconds between checks of the job status. The maximum wait is set with the create timeout.",
+			},
+			"kill_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Kill the job on destroy if it is still running.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Job status, e.g. DONE or ERROR",
+			},
+			"output_files": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the files created by the job",
+			},
+		},
+	}
+}
+
+func resourceJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	tool := d.Get("tool").(string)
+	path, ok := job_tool_paths[tool]
+	if v, set := d.GetOk("run_path"); set {
+		path = v.(string)
+	} else if !ok {
+		return diag.Errorf("No run endpoint known for tool '%s', set run_path", tool)
+	}
+
+	// Each tool has its own run endpoint taking the tool parameters as the
+	// body, jobs/create only registers jobs that have already run
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	if v, ok := d.GetOk("description"); ok {
+		params["jobDescription"] = v.(string)
+	}
+	job, err := submitJob(client, path, d.Get("params").(map[string]interface{}), params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(job.Id)
+	_, err = waitForJob(ctx, client, d.Get("study").(string), job.Id, d.Timeout(schema.TimeoutCreate), d.Get("poll_interval").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceJobRead(ctx, d, m)
+	return diags
+}
+
+func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	job, err := getJob(client, d.Get("study").(string), d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("tool", job.Tool.Id)
+	d.Set("status", job.Internal.Status.Name)
+	d.Set("output_files", flattenJobOutput(job))
+	return diags
+}
+
+func resourceJobUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only settings used while waiting or on destroy can change
+	return resourceJobRead(ctx, d, m)
+}
+
+func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	// Finished jobs are kept in OpenCGA as a record of what was run
+	if d.Get("kill_on_destroy").(bool) {
+		err := killJob(client, d.Get("study").(string), d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func submitJob(client *APIClient, path string, payload interface{}, params map[string]string) (*Job, error) {
+	// Analysis and operation endpoints all return the submitted job
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, fmt.Errorf("Failed to submit job, got %d results", len(resp.Results))
+	}
+	var job Job
+	err = mapstructure.Decode(resp.Results[0], &job)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("submitted job: %s", job.Id)
+	return &job, nil
+}
+
+func getJob(client *APIClient, study string, id string) (*Job, error) {
+	path := fmt.Sprintf("jobs/%s/info", id)
+	params := make(map[string]string)
+	if study != "" {
+		params["study"] = study
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, fmt.Errorf("Failed to find job, got %d results", len(resp.Results))
+	}
+	var job Job
+	err = mapstructure.Decode(resp.Results[0], &job)
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func waitForJob(ctx context.Context, client *APIClient, study string, id string, timeout time.Duration, interval int) (*Job, error) {
+	// Poll the job until it is DONE, failed jobs return an error with the
+	// end of their stderr log
+	conf := &resource.StateChangeConf{
+		Pending:      job_pending_statuses,
+		Target:       []string{"DONE"},
+		Timeout:      timeout,
+		PollInterval: time.Duration(interval) * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			job, err := getJob(client, study, id)
+			if err != nil {
+				return nil, "", err
+			}
+			status := job.Internal.Status.Name
+			if status == "ERROR" || status == "ABORTED" {
+				return nil, status, fmt.Errorf("Job %s finished with status %s:\n%s", id, status, getJobStderr(client, study, id))
+			}
+			return job, status, nil
+		},
+	}
+	job, err := conf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return job.(*Job), nil
+}
+
+func getJobStderr(client *APIClient, study string, id string) string {
+	// Best effort, the log may not exist if the job failed to start
+	path := fmt.Sprintf("jobs/%s/log/tail", id)
+	params := map[string]string{
+		"study": study,
+		"type":  "stderr",
+		"lines": strconv.Itoa(job_stderr_lines),
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return err.Error()
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return fmt.Sprintf("Unable to read stderr: %s", err)
+	}
+	if len(resp.Results) != 1 {
+		return "No stderr available"
+	}
+	var content FileContent
+	err = mapstructure.Decode(resp.Results[0], &content)
+	if err != nil {
+		return err.Error()
+	}
+	return content.Content
+}
+
+func killJob(client *APIClient, study string, id string) error {
+	// Only jobs that have not finished are killed
+	job, err := getJob(client, study, id)
+	if err != nil {
+		return err
+	}
+	if !stringInSlice(job.Internal.Status.Name, job_pending_statuses) {
+		return nil
+	}
+	path := fmt.Sprintf("jobs/%s/kill", id)
+	params := map[string]string{
+		"study": study,
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func flattenJobOutput(job *Job) []string {
+	ids := make([]string, len(job.Output))
+	for i, f := range job.Output {
+		ids[i] = flattenAnnotationValue(f["id"])
+	}
+	return ids
+}
@@ -0,0 +1,239 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Search endpoint of each entity type permission rules can be applied to
+var permission_rule_search_paths = map[string]string{
+	"SAMPLES":           "samples/search",
+	"FILES":             "files/search",
+	"COHORTS":           "cohorts/search",
+	"INDIVIDUALS":       "individuals/search",
+	"FAMILIES":          "families/search",
+	"JOBS":              "jobs/search",
+	"CLINICAL_ANALYSES": "analysis/clinical/search",
+	"DISEASE_PANELS":    "panels/search",
+}
+
+var permission_rule_entities = []string{
+	"SAMPLES", "FILES", "COHORTS", "INDIVIDUALS", "FAMILIES", "JOBS", "CLINICAL_ANALYSES", "DISEASE_PANELS",
+}
+
+func resourcePermissionRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePermissionRuleCreate,
+		ReadContext:   resourcePermissionRuleRead,
+		UpdateContext: resourcePermissionRuleUpdate,
+		DeleteContext: resourcePermissionRuleDelete,
+		CustomizeDiff: resourcePermissionRuleCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study that this rule applies to.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Permission rule id, must be unique within the study.",
+			},
+			"entity": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(permission_rule_entities, false),
+				Description:  "Entity type the rule applies to, e.g. SAMPLES, FILES, INDIVIDUALS, FAMILIES, COHORTS, JOBS, CLINICAL_ANALYSES, DISEASE_PANELS.",
+			},
+			"query": &schema.Schema{
+				Type:        schema.TypeMap,
+				Required:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Entity search parameters selecting the entities the rule applies to, e.g. `{ annotation = \"consent.type=research\" }`.",
+			},
+			"members": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Users or group ids that are granted the permissions.",
+			},
+			"permissions": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "OpenCGA permissions for the entity type, e.g. VIEW, VIEW_ANNOTATIONS.",
+			},
+			"destroy_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "REMOVE",
+				ValidateFunc: validation.StringInSlice([]string{"REMOVE", "REVERT"}, false),
+				Description:  "How the rule is removed on destroy. REMOVE keeps the permissions already granted by the rule, REVERT also removes them.",
+			},
+			"matching_entities": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of entities currently matching the query, this is refreshed on every plan.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("study", "entity", "name"),
+		},
+	}
+}
+
+func resourcePermissionRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	payload := map[string]interface{}{
+		"id":          d.Get("name").(string),
+		"query":       d.Get("query").(map[string]interface{}),
+		"members":     d.Get("members").(*schema.Set).List(),
+		"permissions": d.Get("permissions").(*schema.Set).List(),
+	}
+	err := updatePermissionRule(client, d, "ADD", payload)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("name").(string))
+	resourcePermissionRuleRead(ctx, d, m)
+	return diags
+}
+
+func resourcePermissionRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	rules, err := getPermissionRules(client, d.Get("study").(string), d.Get("entity").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var rule *PermissionRule
+	for i, r := range rules {
+		if r.Id == d.Id() {
+			rule = &rules[i]
+		}
+	}
+	if rule == nil {
+		return diag.Errorf("Failed to find permission rule %s for %s", d.Id(), d.Get("entity"))
+	}
+
+	count, err := countMatchingEntities(client, d.Get("study").(string), d.Get("entity").(string), rule.Query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", rule.Id)
+	d.Set("query", flattenAnnotations(rule.Query))
+	d.Set("members", rule.Members)
+	d.Set("permissions", rule.Permissions)
+	d.Set("matching_entities", count)
+	return diags
+}
+
+func resourcePermissionRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only the destroy action can change and that is not stored in OpenCGA
+	return resourcePermissionRuleRead(ctx, d, m)
+}
+
+func resourcePermissionRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	payload := map[string]interface{}{
+		"id": d.Id(),
+	}
+	err := updatePermissionRule(client, d, d.Get("destroy_action").(string), payload)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourcePermissionRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Preview how many entities the rule applies to as part of the plan
+	if !d.NewValueKnown("study") || !d.NewValueKnown("query") {
+		return nil
+	}
+	client := m.(*APIClient)
+	count, err := countMatchingEntities(
+		client,
+		d.Get("study").(string),
+		d.Get("entity").(string),
+		d.Get("query").(map[string]interface{}),
+	)
+	if err != nil {
+		return err
+	}
+	if d.Id() == "" || d.Get("matching_entities").(int) != count {
+		return d.SetNew("matching_entities", count)
+	}
+	return nil
+}
+
+func updatePermissionRule(client *APIClient, d *schema.ResourceData, action string, payload map[string]interface{}) error {
+	path := fmt.Sprintf("studies/%s/permissionRules/update", d.Get("study"))
+	params := map[string]string{
+		"entity": d.Get("entity").(string),
+		"action": action,
+	}
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func countMatchingEntities(client *APIClient, study string, entity string, query map[string]interface{}) (int, error) {
+	params := map[string]string{
+		"study": study,
+	}
+	for k, v := range query {
+		params[k] = fmt.Sprint(v)
+	}
+	return countSearch(client, permission_rule_search_paths[entity], params)
+}
+
+func countSearch(client *APIClient, path string, params map[string]string) (int, error) {
+	// Only the total is needed so a single result is requested
+	count := map[string]string{
+		"include": "id",
+		"limit":   "1",
+		"count":   "true",
+	}
+	for k, v := range params {
+		count[k] = v
+	}
+	req, err := buildRequest(client, path, nil, count)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return 0, err
+	}
+	return resp.NumTotalResults, nil
+}
@@ -0,0 +1,274 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func resourceStudyACLPolicy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Authoritative ACL for a study. Any member with access to the study that is not declared here, " +
+			"or listed in `excluded_members`, is removed.",
+		CreateContext: resourceStudyACLPolicyCreate,
+		ReadContext:   resourceStudyACLPolicyRead,
+		UpdateContext: resourceStudyACLPolicyUpdate,
+		DeleteContext: resourceStudyACLPolicyDelete,
+		CustomizeDiff: resourceStudyACLPolicyCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study that this policy applies to.",
+			},
+			"acl": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The complete set of members with access to the study.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"member": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "This can be a user name or group id.",
+						},
+						"template": &schema.Schema{
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validateTemplate,
+							Description:      "Preset permissions, can be one of: admin, analyst, view_only.",
+						},
+						"permissions": &schema.Schema{
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "OpenCGA permissions. Refer to OpenCGA docs for allowed values.",
+						},
+					},
+				},
+			},
+			"excluded_members": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Members that are never managed by this policy, e.g. the study owner and `@admins`.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("study"),
+		},
+	}
+}
+
+func resourceStudyACLPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	// Members that already have access but are not declared are removed
+	// straight away, as they would be on any later apply
+	live, err := getStudyACLs(client, d.Get("study").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	declared := expandStudyACLPolicy(d.Get("acl").(*schema.Set))
+	excluded := d.Get("excluded_members").(*schema.Set)
+	for _, acl := range live {
+		if _, ok := declared[acl.Member]; !ok && !excluded.Contains(acl.Member) {
+			if err := resetStudyACL(client, d.Get("study").(string), acl.Member); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	for member, acl := range declared {
+		if err := setStudyACL(client, d.Get("study").(string), member, acl); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(d.Get("study").(string))
+	resourceStudyACLPolicyRead(ctx, d, m)
+	return diags
+}
+
+func resourceStudyACLPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	live, err := getStudyACLs(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Members declared with a template are kept as configured because the
+	// template expansion is not returned by OpenCGA. Every other member is
+	// stored with its live permissions, so undeclared members show up in
+	// the plan as being removed.
+	declared := expandStudyACLPolicy(d.Get("acl").(*schema.Set))
+	excluded := d.Get("excluded_members").(*schema.Set)
+	acls := make([]interface{}, 0)
+	for _, acl := range live {
+		if excluded.Contains(acl.Member) {
+			continue
+		}
+		if v, ok := declared[acl.Member]; ok && v["template"] != "" {
+			acls = append(acls, map[string]interface{}{
+				"member":      acl.Member,
+				"template":    v["template"],
+				"permissions": []interface{}{},
+			})
+			continue
+		}
+		acls = append(acls, map[string]interface{}{
+			"member":      acl.Member,
+			"template":    "",
+			"permissions": stringsToInterfaces(acl.Permissions),
+		})
+	}
+
+	d.Set("study", d.Id())
+	d.Set("acl", acls)
+	return diags
+}
+
+func resourceStudyACLPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	// The state may still hold members that have since been excluded, e.g.
+	// after an import, so the current exclusions are always respected
+	old, new := d.GetChange("acl")
+	oldAcls := expandStudyACLPolicy(old.(*schema.Set))
+	newAcls := expandStudyACLPolicy(new.(*schema.Set))
+	excluded := d.Get("excluded_members").(*schema.Set)
+	for member := range oldAcls {
+		if _, ok := newAcls[member]; !ok && !excluded.Contains(member) {
+			if err := resetStudyACL(client, d.Id(), member); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	for member, acl := range newAcls {
+		if excluded.Contains(member) {
+			continue
+		}
+		if err := setStudyACL(client, d.Id(), member, acl); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStudyACLPolicyRead(ctx, d, m)
+}
+
+func resourceStudyACLPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	excluded := d.Get("excluded_members").(*schema.Set)
+	for member := range expandStudyACLPolicy(d.Get("acl").(*schema.Set)) {
+		if excluded.Contains(member) {
+			continue
+		}
+		if err := resetStudyACL(client, d.Id(), member); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func resourceStudyACLPolicyCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// A member can not be both managed and excluded
+	if !d.NewValueKnown("acl") || !d.NewValueKnown("excluded_members") {
+		return nil
+	}
+	excluded := d.Get("excluded_members").(*schema.Set)
+	for member := range expandStudyACLPolicy(d.Get("acl").(*schema.Set)) {
+		if excluded.Contains(member) {
+			return fmt.Errorf("%s is in both acl and excluded_members", member)
+		}
+	}
+	return nil
+}
+
+func expandStudyACLPolicy(acls *schema.Set) map[string]map[string]interface{} {
+	// Index the acl blocks by member
+	result := make(map[string]map[string]interface{})
+	for _, v := range acls.List() {
+		acl := v.(map[string]interface{})
+		result[acl["member"].(string)] = acl
+	}
+	return result
+}
+
+func getStudyACLs(client *APIClient, study string) ([]StudyACL, error) {
+	path := fmt.Sprintf("studies/%s/acl", study)
+	req, err := buildRequest(client, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	var studyACLs []StudyACL
+	err = mapstructure.Decode(resp.Results, &studyACLs)
+	if err != nil {
+		return nil, err
+	}
+	return studyACLs, nil
+}
+
+func setStudyACL(client *APIClient, study string, member string, acl map[string]interface{}) error {
+	payload := map[string]interface{}{
+		"action": "SET",
+		"study":  study,
+	}
+	permissions := setToStrings(acl["permissions"].(*schema.Set))
+	template := acl["template"].(string)
+	if template == "" && len(permissions) == 0 {
+		return fmt.Errorf("Must provide either template or permissions for %s", member)
+	}
+	if template != "" && len(permissions) > 0 {
+		return fmt.Errorf("Must provide either template or permissions for %s but not both", member)
+	}
+	if template != "" {
+		payload["template"] = template
+	} else {
+		payload["permissions"] = strings.Join(permissions, ",")
+	}
+
+	path := fmt.Sprintf("studies/acl/%s/update", member)
+	req, err := buildRequest(client, path, payload, nil)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func resetStudyACL(client *APIClient, study string, member string) error {
+	// RESET removes the member from the study ACL entirely
+	payload := map[string]interface{}{
+		"action": "RESET",
+		"study":  study,
+	}
+	path := fmt.Sprintf("studies/acl/%s/update", member)
+	req, err := buildRequest(client, path, payload, nil)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
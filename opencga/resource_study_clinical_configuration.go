@@ -0,0 +1,366 @@
+package opencga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+var clinical_status_types = []string{"NOT_STARTED", "IN_PROGRESS", "CLOSED", "UNKNOWN"}
+
+func resourceStudyClinicalConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Clinical configuration of a study. Only the sections declared here are managed, " +
+			"the rest of the configuration is left unchanged.",
+		CreateContext: resourceStudyClinicalConfigurationCreate,
+		ReadContext:   resourceStudyClinicalConfigurationRead,
+		UpdateContext: resourceStudyClinicalConfigurationUpdate,
+		DeleteContext: resourceStudyClinicalConfigurationDelete,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study to configure.",
+			},
+			"priority": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Priorities that can be given to clinical analyses.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"rank": &schema.Schema{
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Lower ranks are more urgent",
+						},
+						"default": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Priority given to new clinical analyses",
+						},
+					},
+				},
+			},
+			"flags": clinicalTypeValuesSchema("Flags that can be set on clinical analyses of each type.", false),
+			"status": clinicalTypeValuesSchema(
+				"Status workflow of clinical analyses of each type, in the order they are used.", true),
+			"interpretation_status": clinicalTypeValuesSchema(
+				"Status workflow of interpretations of each clinical analysis type, in the order they are used.", true),
+			"interpretation_default_filter": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description:      "JSON encoded variant query used by default when interpreting clinical analyses.",
+			},
+			"consent": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Consents that can be recorded for the participants of clinical analyses.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("study"),
+		},
+	}
+}
+
+func clinicalTypeValuesSchema(description string, status bool) *schema.Schema {
+	// Statuses and flags are both lists of values per clinical analysis type
+	value := map[string]*schema.Schema{
+		"id": &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+	if status {
+		value["type"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(clinical_status_types, false),
+			Description:  "Can be one of: NOT_STARTED, IN_PROGRESS, CLOSED, UNKNOWN",
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"clinical_type": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice(clinical_analysis_types, false),
+					Description:  "Clinical analysis type, e.g. SINGLE, FAMILY",
+				},
+				"value": &schema.Schema{
+					Type:     schema.TypeList,
+					Required: true,
+					Elem:     &schema.Resource{Schema: value},
+				},
+			},
+		},
+	}
+}
+
+func resourceStudyClinicalConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	err := updateStudyClinicalConfiguration(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("study").(string))
+	resourceStudyClinicalConfigurationRead(ctx, d, m)
+	return diags
+}
+
+func resourceStudyClinicalConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	raw, err := getStudyClinicalConfiguration(client, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var config ClinicalConfiguration
+	err = mapstructure.Decode(raw, &config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Sections that are not declared are not managed so are left out of
+	// the state, otherwise they would show up as being removed
+	d.Set("study", d.Id())
+	if _, ok := d.GetOk("priority"); ok {
+		priorities := make([]interface{}, len(config.Priorities))
+		for i, p := range config.Priorities {
+			priorities[i] = map[string]interface{}{
+				"id":          p.Id,
+				"description": p.Description,
+				"rank":        p.Rank,
+				"default":     p.DefaultPriority,
+			}
+		}
+		d.Set("priority", priorities)
+	}
+	if _, ok := d.GetOk("flags"); ok {
+		flags := make(map[string][]ClinicalStatusValue)
+		for k, values := range config.Flags {
+			for _, v := range values {
+				flags[k] = append(flags[k], ClinicalStatusValue{Id: v.Id, Description: v.Description})
+			}
+		}
+		d.Set("flags", flattenClinicalTypeValues(flags, false))
+	}
+	if _, ok := d.GetOk("status"); ok {
+		d.Set("status", flattenClinicalTypeValues(config.Status, true))
+	}
+	if _, ok := d.GetOk("interpretation_status"); ok {
+		d.Set("interpretation_status", flattenClinicalTypeValues(config.Interpretation.Status, true))
+	}
+	if _, ok := d.GetOk("interpretation_default_filter"); ok {
+		filter, err := json.Marshal(config.Interpretation.DefaultFilter)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("interpretation_default_filter", string(filter))
+	}
+	if _, ok := d.GetOk("consent"); ok {
+		consents := make([]interface{}, len(config.Consent.Consents))
+		for i, c := range config.Consent.Consents {
+			consents[i] = map[string]interface{}{
+				"id":          c.Id,
+				"name":        c.Name,
+				"description": c.Description,
+			}
+		}
+		d.Set("consent", consents)
+	}
+	return diags
+}
+
+func resourceStudyClinicalConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	err := updateStudyClinicalConfiguration(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceStudyClinicalConfigurationRead(ctx, d, m)
+}
+
+func resourceStudyClinicalConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// A study always has a clinical configuration so it is left as it is
+	d.SetId("")
+	return diags
+}
+
+func getStudyClinicalConfiguration(client *APIClient, study string) (map[string]interface{}, error) {
+	// The raw configuration is returned so that sections and fields the
+	// provider does not know about are sent back unchanged
+	path := fmt.Sprintf("studies/%s/info", study)
+	params := map[string]string{
+		"include": "internal.configuration.clinical",
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Results) != 1 {
+		return nil, fmt.Errorf("Failed to find study, got %d results", len(resp.Results))
+	}
+	config, _ := resp.Results[0].(map[string]interface{})
+	for _, key := range []string{"internal", "configuration", "clinical"} {
+		config, _ = config[key].(map[string]interface{})
+	}
+	if config == nil {
+		config = make(map[string]interface{})
+	}
+	return config, nil
+}
+
+func updateStudyClinicalConfiguration(client *APIClient, d *schema.ResourceData) error {
+	config, err := getStudyClinicalConfiguration(client, d.Get("study").(string))
+	if err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk("priority"); ok {
+		priorities := make([]interface{}, 0)
+		for _, p := range v.([]interface{}) {
+			priority := p.(map[string]interface{})
+			priorities = append(priorities, map[string]interface{}{
+				"id":              priority["id"],
+				"description":     priority["description"],
+				"rank":            priority["rank"],
+				"defaultPriority": priority["default"],
+			})
+		}
+		config["priorities"] = priorities
+	}
+	if v, ok := d.GetOk("flags"); ok {
+		config["flags"] = expandClinicalTypeValues(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("status"); ok {
+		config["status"] = expandClinicalTypeValues(v.(*schema.Set))
+	}
+	interpretation, _ := config["interpretation"].(map[string]interface{})
+	if interpretation == nil {
+		interpretation = make(map[string]interface{})
+	}
+	if v, ok := d.GetOk("interpretation_status"); ok {
+		interpretation["status"] = expandClinicalTypeValues(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("interpretation_default_filter"); ok {
+		var filter map[string]interface{}
+		if err := json.Unmarshal([]byte(v.(string)), &filter); err != nil {
+			return err
+		}
+		interpretation["defaultFilter"] = filter
+	}
+	config["interpretation"] = interpretation
+	if v, ok := d.GetOk("consent"); ok {
+		config["consent"] = map[string]interface{}{
+			"consents": v.([]interface{}),
+		}
+	}
+
+	path := fmt.Sprintf("studies/%s/configuration/clinical/update", d.Get("study"))
+	req, err := buildRequest(client, path, config, nil)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func expandClinicalTypeValues(set *schema.Set) map[string]interface{} {
+	// Group the values of each block by clinical analysis type
+	result := make(map[string]interface{})
+	for _, v := range set.List() {
+		block := v.(map[string]interface{})
+		result[block["clinical_type"].(string)] = block["value"]
+	}
+	return result
+}
+
+func flattenClinicalTypeValues(values map[string][]ClinicalStatusValue, status bool) []interface{} {
+	types := make([]string, 0, len(values))
+	for k := range values {
+		types = append(types, k)
+	}
+	sort.Strings(types)
+	result := make([]interface{}, len(types))
+	for i, k := range types {
+		items := make([]interface{}, len(values[k]))
+		for j, v := range values[k] {
+			item := map[string]interface{}{
+				"id":          v.Id,
+				"description": v.Description,
+			}
+			if status {
+				item["type"] = v.Type
+			}
+			items[j] = item
+		}
+		result[i] = map[string]interface{}{
+			"clinical_type": k,
+			"value":         items,
+		}
+	}
+	return result
+}
@@ -31,6 +31,40 @@ func resourceStudyGroup() *schema.Resource {
 				Required:    true,
 				Description: "The study that this group belongs to",
 			},
+			"sync_from": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Description: "Synchronise the group members from a group of an external authentication origin, e.g. LDAP or AD. " +
+					"Removing this block does not stop the synchronisation of the group in OpenCGA.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authentication_origin": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Id of the authentication origin configured in OpenCGA",
+						},
+						"remote_group": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Group name or DN in the authentication origin, e.g. cn=analysts,ou=groups,dc=mycompany,dc=com",
+						},
+					},
+				},
+			},
+			"sync_triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that cause the group to be synchronised again when changed, e.g. a timestamp.",
+			},
+			"users": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Users that are members of the group",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -63,6 +97,14 @@ func resourceStudyGroupCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	d.SetId(studyGroup.Id)
+
+	if _, ok := d.GetOk("sync_from"); ok {
+		err = syncStudyGroup(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	resourceStudyGroupRead(ctx, d, m)
 	return diags
 }
@@ -95,10 +137,27 @@ func resourceStudyGroupRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	d.SetId(studyGroups[0].Id)
 	d.Set("name", studyGroups[0].Name)
+	d.Set("users", studyGroups[0].UserIds)
+	if studyGroups[0].SyncedFrom.AuthOrigin != "" {
+		d.Set("sync_from", []interface{}{
+			map[string]interface{}{
+				"authentication_origin": studyGroups[0].SyncedFrom.AuthOrigin,
+				"remote_group":          studyGroups[0].SyncedFrom.RemoteGroup,
+			},
+		})
+	}
 	return diags
 }
 
 func resourceStudyGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	if _, ok := d.GetOk("sync_from"); ok && d.HasChanges("sync_from", "sync_triggers") {
+		err := syncStudyGroup(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceStudyGroupRead(ctx, d, m)
 }
 
@@ -108,3 +167,22 @@ func resourceStudyGroupDelete(ctx context.Context, d *schema.ResourceData, m int
 	log.Printf("Pretending to delete but doing nothing....")
 	return diags
 }
+
+func syncStudyGroup(client *APIClient, d *schema.ResourceData) error {
+	// Replace the group members with those of the remote group
+	sync := d.Get("sync_from").([]interface{})[0].(map[string]interface{})
+	payload := map[string]interface{}{
+		"authenticationOriginId": sync["authentication_origin"].(string),
+		"from":                   sync["remote_group"].(string),
+		"to":                     d.Id(),
+		"study":                  d.Get("study").(string),
+		"force":                  true,
+	}
+	path := "admin/users/sync"
+	req, err := buildRequest(client, path, payload, nil)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
@@ -0,0 +1,130 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func resourceStudyGroupMember() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Adds a single user to a study group without managing the other members of the group.",
+		CreateContext: resourceStudyGroupMemberCreate,
+		ReadContext:   resourceStudyGroupMemberRead,
+		DeleteContext: resourceStudyGroupMemberDelete,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The study that the group belongs to",
+			},
+			"group": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Group id, e.g. @members",
+			},
+			"user": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The user id to add to the group",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("study", "group", "user"),
+		},
+	}
+}
+
+func resourceStudyGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	err := updateStudyGroupUsers(client, d.Get("study").(string), d.Get("group").(string), "ADD", []string{d.Get("user").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("user").(string))
+	resourceStudyGroupMemberRead(ctx, d, m)
+	return diags
+}
+
+func resourceStudyGroupMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("studies/%s/groups", d.Get("study"))
+	params := map[string]string{
+		"name": d.Get("group").(string),
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find study group, got %d results", len(resp.Results))
+	}
+	var studyGroups []StudyGroup
+	err = mapstructure.Decode(resp.Results, &studyGroups)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The user may have been removed from the group outside of terraform
+	if !stringInSlice(d.Id(), studyGroups[0].UserIds) {
+		log.Printf("User %s is no longer a member of %s", d.Id(), d.Get("group"))
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("user", d.Id())
+	return diags
+}
+
+func resourceStudyGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	err := updateStudyGroupUsers(client, d.Get("study").(string), d.Get("group").(string), "REMOVE", []string{d.Id()})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func updateStudyGroupUsers(client *APIClient, study string, group string, action string, users []string) error {
+	// ADD and REMOVE only change the given users, other group members are untouched
+	payload := map[string]interface{}{
+		"users": users,
+	}
+	params := map[string]string{
+		"action": action,
+	}
+	path := fmt.Sprintf("studies/%s/groups/%s/update", study, group)
+	req, err := buildRequest(client, path, payload, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
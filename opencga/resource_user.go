@@ -0,0 +1,291 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+var user_account_types = []string{"GUEST", "FULL", "ADMINISTRATOR"}
+
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"user_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Login name of the user, this is the value used as an ACL or group member.",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Full name of the user, required for internal users. Users from external origins keep the name from the origin when not set.",
+			},
+			"email": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Email address of the user, required for internal users. Users from external origins keep the email from the origin when not set.",
+			},
+			"organization": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Organization the user belongs to",
+			},
+			"account_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "FULL",
+				ValidateFunc: validation.StringInSlice(user_account_types, false),
+				Description:  "Account type, can be one of: GUEST, FULL, ADMINISTRATOR.",
+			},
+			"authentication_origin": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "internal",
+				Description: "Id of the authentication origin configured in OpenCGA, e.g. an LDAP origin. Users from external origins are imported rather than created and have no password.",
+			},
+			"password": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: passwordDiffSuppressFunc,
+				Description:      "Initial password for internal users. This is only used on creation and is removed from the state once the user is created, so later changes are ignored.",
+			},
+			"disk_quota": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum disk usage allowed for the user in bytes.",
+			},
+			"cpu_quota": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum cpu usage allowed for the user.",
+			},
+			"destroy_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DISABLE",
+				ValidateFunc: validation.StringInSlice([]string{"DISABLE", "DELETE"}, false),
+				Description:  "What to do with the account on destroy, can be one of: DISABLE, DELETE. Disabled accounts keep their catalog history.",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Internal status of the account, e.g. READY or BANNED.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	var path string
+	var payload map[string]interface{}
+	origin := d.Get("authentication_origin").(string)
+	if origin == "internal" {
+		password, ok := d.GetOk("password")
+		if !ok {
+			return diag.Errorf("Must supply a password for internal users")
+		}
+		for _, attribute := range []string{"name", "email"} {
+			if _, ok := d.GetOk(attribute); !ok {
+				return diag.Errorf("Must supply a %s for internal users", attribute)
+			}
+		}
+		path = "admin/users/create"
+		payload = map[string]interface{}{
+			"id":           d.Get("user_id").(string),
+			"name":         d.Get("name").(string),
+			"email":        d.Get("email").(string),
+			"organization": d.Get("organization").(string),
+			"password":     password.(string),
+			"type":         d.Get("account_type").(string),
+		}
+	} else {
+		// External users are imported, their details come from the origin
+		if _, ok := d.GetOk("password"); ok {
+			return diag.Errorf("Password cannot be set for users from authentication origin %s", origin)
+		}
+		path = "admin/users/import"
+		payload = map[string]interface{}{
+			"authenticationOriginId": origin,
+			"id":                     []string{d.Get("user_id").(string)},
+			"resourceType":           "user",
+			"type":                   d.Get("account_type").(string),
+		}
+	}
+
+	req, err := buildRequest(client, path, payload, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var user User
+	err = mapstructure.Decode(resp.Results[0], &user)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(user.Id)
+
+	// The password is not kept in the state, it is only needed to create
+	// the user and later diffs are suppressed
+	d.Set("password", "")
+
+	// Name, email and quota are not part of the import request so are
+	// always applied as a follow up update
+	err = updateUser(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceUserRead(ctx, d, m)
+	return diags
+}
+
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("users/%s/info", d.Id())
+	params := map[string]string{
+		"exclude": "projects",
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find user, got %d results", len(resp.Results))
+	}
+	var user User
+	err = mapstructure.Decode(resp.Results[0], &user)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("user_id", user.Id)
+	d.Set("name", user.Name)
+	d.Set("email", user.Email)
+	d.Set("organization", user.Organization)
+	d.Set("account_type", user.Account.Type)
+	d.Set("authentication_origin", user.Account.Authentication.Id)
+	d.Set("disk_quota", user.Quota.MaxDisk)
+	d.Set("cpu_quota", user.Quota.MaxCpu)
+	d.Set("status", user.Internal.Status.Name)
+	return diags
+}
+
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	if d.HasChanges("name", "email", "organization", "disk_quota", "cpu_quota") {
+		err := updateUser(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return resourceUserRead(ctx, d, m)
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	var path string
+	var payload interface{}
+	if d.Get("destroy_action").(string) == "DELETE" {
+		path = fmt.Sprintf("users/%s/delete", d.Id())
+	} else {
+		// Banned users can no longer log in but remain as ACL members
+		path = fmt.Sprintf("admin/users/%s/update", d.Id())
+		payload = map[string]interface{}{
+			"internal": map[string]interface{}{
+				"status": map[string]string{"name": "BANNED"},
+			},
+		}
+	}
+	req, err := buildRequest(client, path, payload, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, err = client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return diags
+}
+
+func updateUser(client *APIClient, d *schema.ResourceData) error {
+	// Details of users from external origins come from the origin, so
+	// only the ones set in the configuration are written
+	internal := d.Get("authentication_origin").(string) == "internal"
+	payload := make(map[string]interface{})
+	for _, attribute := range []string{"name", "email", "organization"} {
+		if v, ok := d.GetOk(attribute); ok || internal {
+			payload[attribute] = v.(string)
+		}
+	}
+	quota := make(map[string]interface{})
+	if v, ok := d.GetOk("disk_quota"); ok {
+		quota["maxDisk"] = v.(int)
+	}
+	if v, ok := d.GetOk("cpu_quota"); ok {
+		quota["maxCpu"] = v.(int)
+	}
+	if len(quota) > 0 {
+		payload["quota"] = quota
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+
+	path := fmt.Sprintf("users/%s/update", d.Id())
+	req, err := buildRequest(client, path, payload, nil)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func passwordDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	// The password is only sent on creation, once the user exists it
+	// may be changed by the user so should not be compared
+	return d.Id() != ""
+}
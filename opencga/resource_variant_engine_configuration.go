@@ -0,0 +1,226 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/mapstructure"
+)
+
+func resourceVariantEngineConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "CellBase and variant storage engine configuration of a project. " +
+			"Only the engine options declared here are managed, other options keep their current values.",
+		CreateContext: resourceVariantEngineConfigurationCreate,
+		ReadContext:   resourceVariantEngineConfigurationRead,
+		UpdateContext: resourceVariantEngineConfigurationUpdate,
+		DeleteContext: resourceVariantEngineConfigurationDelete,
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"project": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the project to configure.",
+			},
+			"cellbase": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "CellBase instance used to annotate the variants of the project. The current CellBase configuration is read when this is not set, removing it keeps the project configuration unchanged.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "CellBase url, e.g. https://ws.zettagenomics.com/cellbase",
+						},
+						"version": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "CellBase version, e.g. v5.1",
+						},
+						"data_release": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "CellBase data release, the current data release is kept when not set",
+						},
+					},
+				},
+			},
+			"annotation_update": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Update the variant annotation of the project when the CellBase configuration changes.",
+			},
+			"variant_options": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Variant storage engine options, e.g. `{ \"annotator\" = \"cellbase\" }`",
+			},
+			"storage_engine": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Variant storage engine of the project, e.g. hadoop",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateIdFunc("project"),
+		},
+	}
+}
+
+func resourceVariantEngineConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	if _, ok := d.GetOk("cellbase"); ok {
+		err := configureCellbase(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if v, ok := d.GetOk("variant_options"); ok {
+		err := configureVariantEngine(client, d.Get("project").(string), v.(map[string]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(d.Get("project").(string))
+	resourceVariantEngineConfigurationRead(ctx, d, m)
+	return diags
+}
+
+func resourceVariantEngineConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	path := fmt.Sprintf("projects/%s/info", d.Id())
+	params := map[string]string{
+		"include": "id,cellbase,internal.datastores",
+	}
+	req, err := buildRequest(client, path, nil, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := client.Call(req)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resp.Results) != 1 {
+		return diag.Errorf("Failed to find project, got %d results", len(resp.Results))
+	}
+	var project Project
+	err = mapstructure.Decode(resp.Results[0], &project)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if project.Cellbase.Url != "" {
+		d.Set("cellbase", []interface{}{
+			map[string]interface{}{
+				"url":          project.Cellbase.Url,
+				"version":      project.Cellbase.Version,
+				"data_release": project.Cellbase.DataRelease,
+			},
+		})
+	}
+
+	// The engine has many default options, only compare the declared ones
+	options := make(map[string]interface{})
+	live := flattenAnnotations(project.Internal.Datastores.Variant.Options)
+	for k := range d.Get("variant_options").(map[string]interface{}) {
+		if v, ok := live[k]; ok {
+			options[k] = v
+		}
+	}
+
+	d.Set("project", d.Id())
+	d.Set("variant_options", options)
+	d.Set("storage_engine", project.Internal.Datastores.Variant.StorageEngine)
+	return diags
+}
+
+func resourceVariantEngineConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	if d.HasChange("cellbase") {
+		if _, ok := d.GetOk("cellbase"); ok {
+			err := configureCellbase(client, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	if d.HasChange("variant_options") {
+		// Options removed from the configuration are reset to their defaults
+		old, new := d.GetChange("variant_options")
+		options := new.(map[string]interface{})
+		for k := range old.(map[string]interface{}) {
+			if _, ok := options[k]; !ok {
+				options[k] = nil
+			}
+		}
+		err := configureVariantEngine(client, d.Id(), options)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceVariantEngineConfigurationRead(ctx, d, m)
+}
+
+func resourceVariantEngineConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// The project keeps its configuration, it can not be left without one
+	d.SetId("")
+	return diags
+}
+
+func configureCellbase(client *APIClient, d *schema.ResourceData) error {
+	cellbase := d.Get("cellbase").([]interface{})[0].(map[string]interface{})
+	payload := map[string]interface{}{
+		"url":     cellbase["url"].(string),
+		"version": cellbase["version"].(string),
+	}
+	if cellbase["data_release"].(string) != "" {
+		payload["dataRelease"] = cellbase["data_release"].(string)
+	}
+	params := map[string]string{
+		"project":          d.Get("project").(string),
+		"annotationUpdate": fmt.Sprint(d.Get("annotation_update").(bool)),
+	}
+	req, err := buildRequest(client, "operation/cellbase/configure", payload, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
+
+func configureVariantEngine(client *APIClient, project string, options map[string]interface{}) error {
+	// Options not in the body are left unchanged
+	params := map[string]string{
+		"project": project,
+	}
+	req, err := buildRequest(client, "operation/variant/configure", options, params)
+	if err != nil {
+		return err
+	}
+	_, err = client.Call(req)
+	return err
+}
@@ -0,0 +1,278 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Number of files whose index status is read in a single request
+const file_index_status_batch_size = 100
+
+func resourceVariantIndex() *schema.Resource {
+	return &schema.Resource{
+		Description: "Indexes VCF files into the variant storage of a study. Files that are not indexed, " +
+			"e.g. because a previous index job failed, are indexed again on the next apply. " +
+			"Removing a file from `files` does not remove it from the variant storage.",
+		CreateContext: resourceVariantIndexCreate,
+		ReadContext:   resourceVariantIndexRead,
+		UpdateContext: resourceVariantIndexUpdate,
+		DeleteContext: resourceVariantIndexDelete,
+		CustomizeDiff: resourceVariantIndexCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+			Update: schema.DefaultTimeout(120 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"study": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the study the files belong to.",
+			},
+			"files": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the VCF files to index, e.g. `opencga_file.vcf.id`",
+			},
+			"stage": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ALL",
+				ValidateFunc: validation.StringInSlice([]string{"ALL", "TRANSFORM", "LOAD"}, false),
+				Description:  "Index stage to run, can be one of: ALL, TRANSFORM, LOAD. LOAD expects files that have already been transformed.",
+			},
+			"annotate": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Annotate the new variants once loaded.",
+			},
+			"calculate_stats": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Calculate the variant stats of the study once loaded.",
+			},
+			"resume": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Resume a previously failed index operation.",
+			},
+			"poll_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Seconds between checks of the index job status.",
+			},
+			"file_index_status": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Variant index status of each file, e.g. READY, TRANSFORMED, NONE",
+			},
+			"job_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Ids of the index jobs submitted by this resource",
+			},
+		},
+	}
+}
+
+func resourceVariantIndexCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	files := setToStrings(d.Get("files").(*schema.Set))
+	job, err := indexVariantFiles(ctx, d, m, files, d.Timeout(schema.TimeoutCreate))
+	if job != nil {
+		d.SetId(job.Id)
+		d.Set("job_ids", []string{job.Id})
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceVariantIndexRead(ctx, d, m)
+	return diags
+}
+
+func resourceVariantIndexRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	statuses, err := getFileIndexStatus(client, d.Get("study").(string), setToStrings(d.Get("files").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("file_index_status", statuses)
+	return diags
+}
+
+func resourceVariantIndexUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*APIClient)
+
+	// Only files that have not reached the target status are indexed, any
+	// change to the options applies to those files. The planned statuses
+	// already show every file at the target, so the server is asked again
+	target := variantIndexTargetStatus(d.Get("stage").(string))
+	statuses, err := getFileIndexStatus(client, d.Get("study").(string), setToStrings(d.Get("files").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	files := make([]string, 0)
+	for _, file := range setToStrings(d.Get("files").(*schema.Set)) {
+		if statuses[file] != target {
+			files = append(files, file)
+		}
+	}
+
+	// Job ids are unknown in the plan, so keep the ids from the state
+	jobIds, _ := d.GetChange("job_ids")
+	d.Set("job_ids", jobIds)
+
+	if len(files) > 0 {
+		job, err := indexVariantFiles(ctx, d, m, files, d.Timeout(schema.TimeoutUpdate))
+		if job != nil {
+			d.Set("job_ids", append(jobIds.([]interface{}), job.Id))
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceVariantIndexRead(ctx, d, m)
+}
+
+func resourceVariantIndexDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// Variants are kept in the storage, they are only removed by deleting
+	// the files from the study
+	d.SetId("")
+	return diags
+}
+
+func resourceVariantIndexCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Plan the files that will be indexed by showing them reaching the
+	// target status
+	if d.Id() == "" || !d.NewValueKnown("files") {
+		return nil
+	}
+	target := variantIndexTargetStatus(d.Get("stage").(string))
+	statuses := d.Get("file_index_status").(map[string]interface{})
+	planned := make(map[string]interface{})
+	changed := d.HasChange("files")
+	for _, file := range setToStrings(d.Get("files").(*schema.Set)) {
+		if statuses[file] != target {
+			changed = true
+		}
+		planned[file] = target
+	}
+	if !changed {
+		return nil
+	}
+	if err := d.SetNew("file_index_status", planned); err != nil {
+		return err
+	}
+	return d.SetNewComputed("job_ids")
+}
+
+func indexVariantFiles(ctx context.Context, d *schema.ResourceData, m interface{}, files []string, timeout time.Duration) (*Job, error) {
+	client := m.(*APIClient)
+
+	payload := map[string]interface{}{
+		"file":           strings.Join(files, ","),
+		"annotate":       d.Get("annotate").(bool),
+		"calculateStats": d.Get("calculate_stats").(bool),
+		"resume":         d.Get("resume").(bool),
+	}
+	// OpenCGA runs both stages unless one of them is requested
+	switch d.Get("stage").(string) {
+	case "TRANSFORM":
+		payload["transform"] = true
+	case "LOAD":
+		payload["load"] = true
+	}
+	params := map[string]string{
+		"study": d.Get("study").(string),
+	}
+	job, err := submitJob(client, "operation/variant/index", payload, params)
+	if err != nil {
+		return nil, err
+	}
+	_, err = waitForJob(ctx, client, d.Get("study").(string), job.Id, timeout, d.Get("poll_interval").(int))
+	return job, err
+}
+
+func getFileIndexStatus(client *APIClient, study string, files []string) (map[string]string, error) {
+	statuses := make(map[string]string)
+	for start := 0; start < len(files); start += file_index_status_batch_size {
+		end := start + file_index_status_batch_size
+		if end > len(files) {
+			end = len(files)
+		}
+		path := fmt.Sprintf("files/%s/info", strings.Join(files[start:end], ","))
+		params := map[string]string{
+			"study":   study,
+			"include": "id,internal.variant.index",
+		}
+		req, err := buildRequest(client, path, nil, params)
+		if err != nil {
+			return nil, err
+		}
+		// Depending on the OpenCGA version the files come back as one
+		// response each or as the results of a single response, so they
+		// are matched to the requested ids by their id
+		responses, err := client.CallAll(req)
+		if err != nil {
+			return nil, err
+		}
+		for _, resp := range responses {
+			var results []File
+			err = mapstructure.Decode(resp.Results, &results)
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range results {
+				status := file.Internal.Variant.Index.Status.Name
+				if status == "" {
+					status = "NONE"
+				}
+				statuses[strconv.Itoa(file.Id)] = status
+			}
+		}
+	}
+	for _, file := range files {
+		if _, ok := statuses[file]; !ok {
+			return nil, fmt.Errorf("Failed to find file %s in study %s", file, study)
+		}
+	}
+	return statuses, nil
+}
+
+func variantIndexTargetStatus(stage string) string {
+	if stage == "TRANSFORM" {
+		return "TRANSFORMED"
+	}
+	return "READY"
+}
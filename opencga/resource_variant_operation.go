@@ -0,0 +1,209 @@
+package opencga
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Endpoint of each variant storage operation
+var variant_operation_paths = map[string]string{
+	"ANNOTATION_INDEX": "operation/variant/annotation/index",
+	"SECONDARY_INDEX":  "operation/variant/secondaryIndex",
+	"SAMPLE_INDEX":     "operation/variant/sample/index",
+}
+
+// Name of the overwrite option in the body of each operation
+var variant_operation_overwrite = map[string]string{
+	"ANNOTATION_INDEX": "overwriteAnnotations",
+	"SECONDARY_INDEX":  "overwrite",
+	"SAMPLE_INDEX":     "overwrite",
+}
+
+var variant_operations = []string{"ANNOTATION_INDEX", "SECONDARY_INDEX", "SAMPLE_INDEX"}
+
+func resourceVariantOperation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Runs a variant storage operation on a project or study, e.g. annotation or secondary index. " +
+			"The operation runs again when any of `triggers` change.",
+		CreateContext: resourceVariantOperationCreate,
+		ReadContext:   resourceVariantOperationRead,
+		UpdateContext: resourceVariantOperationUpdate,
+		DeleteContext: resourceVariantOperationDelete,
+		CustomizeDiff: resourceVariantOperationCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(120 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"operation": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(variant_operations, false),
+				Description:  "Operation to run, can be one of: ANNOTATION_INDEX, SECONDARY_INDEX, SAMPLE_INDEX.",
+			},
+			"project": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"project", "study"},
+				Description:  "The project to run the operation on. SAMPLE_INDEX can only run on a study.",
+			},
+			"study": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"project", "study"},
+				Description:  "The study to run the operation on.",
+			},
+			"samples": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Samples to rebuild the sample index for, all samples of the study by default. Only used by SAMPLE_INDEX.",
+			},
+			"overwrite": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Rebuild the whole index rather than only the new variants.",
+			},
+			"params": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional parameters for the operation, e.g. `{ region = \"22\" }`",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the operation again when changed, e.g. the id of a data release.",
+			},
+			"poll_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Seconds between checks of the job status.",
+			},
+			"job_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Id of the job that ran the operation",
+			},
+			"job_study": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Study the job ran in, one of the project studies for project operations",
+			},
+			"status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Job status, e.g. DONE or ERROR",
+			},
+		},
+	}
+}
+
+func resourceVariantOperationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	operation := d.Get("operation").(string)
+
+	payload := make(map[string]interface{})
+	for k, v := range d.Get("params").(map[string]interface{}) {
+		payload[k] = v
+	}
+	payload[variant_operation_overwrite[operation]] = d.Get("overwrite").(bool)
+	if operation == "SAMPLE_INDEX" {
+		samples := d.Get("samples").([]interface{})
+		if len(samples) == 0 {
+			samples = []interface{}{"all"}
+		}
+		payload["sample"] = samples
+		payload["buildIndex"] = true
+	}
+	params := make(map[string]string)
+	if v, ok := d.GetOk("project"); ok {
+		params["project"] = v.(string)
+	}
+	if v, ok := d.GetOk("study"); ok {
+		params["study"] = v.(string)
+	}
+
+	job, err := submitJob(client, variant_operation_paths[operation], payload, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(job.Id)
+	d.Set("job_id", job.Id)
+	d.Set("job_study", jobStudy(d, job))
+	_, err = waitForJob(ctx, client, d.Get("job_study").(string), job.Id, d.Timeout(schema.TimeoutCreate), d.Get("poll_interval").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceVariantOperationRead(ctx, d, m)
+	return diags
+}
+
+func resourceVariantOperationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+	client := m.(*APIClient)
+
+	job, err := getJob(client, d.Get("job_study").(string), d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("job_id", job.Id)
+	d.Set("status", job.Internal.Status.Name)
+	return diags
+}
+
+func resourceVariantOperationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only the poll interval can change without running the operation again
+	return resourceVariantOperationRead(ctx, d, m)
+}
+
+func resourceVariantOperationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// Nothing to undo, the job is kept in OpenCGA
+	d.SetId("")
+	return diags
+}
+
+func resourceVariantOperationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// The sample index is stored per study so can't be built for a project
+	if d.Get("operation").(string) == "SAMPLE_INDEX" && d.NewValueKnown("study") && d.Get("study").(string) == "" {
+		return fmt.Errorf("SAMPLE_INDEX must be run on a study")
+	}
+	return nil
+}
+
+func jobStudy(d *schema.ResourceData, job *Job) string {
+	// Project operations run in one of the project studies
+	if v, ok := d.GetOk("study"); ok {
+		return v.(string)
+	}
+	return job.Study.Id
+}